@@ -0,0 +1,103 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import "errors"
+
+// An Acknowledge is a decoded AIS Binary Acknowledge (message type 7) or Safety Related
+// Acknowledge (message type 13), which share the same layout. A station acknowledges up to four
+// addressed messages (types 6 or 12) it received.
+// Please have a look at http://catb.org/gpsd/AIVDM.html
+type Acknowledge struct {
+	Type   uint8 // 7 or 13
+	Repeat uint8
+	MMSI   uint32 // The acknowledging station, destination of the acknowledged messages
+	Acks   []Acknowledgement
+}
+
+// An Acknowledgement identifies an acknowledged message by its source and sequence number.
+type Acknowledgement struct {
+	MMSI     uint32 // Source of the acknowledged message
+	Sequence uint8  // Sequence number of the acknowledged message
+}
+
+// DecodeBinaryAcknowledge decodes [the payload of] an AIS Binary Acknowledge (type 7), sent in
+// reply to Binary Addressed messages (type 6).
+func DecodeBinaryAcknowledge(payload string) (Acknowledge, error) {
+	data := []byte(payload)
+
+	mType := decodeAisChar(data[0])
+	if mType != 7 {
+		var m Acknowledge
+		return m, errors.New("Message isn't Binary Acknowledge (type 7).")
+	}
+
+	return decodeAcknowledge(data)
+}
+
+// DecodeSafetyAcknowledge decodes [the payload of] an AIS Safety Related Acknowledge (type 13),
+// sent in reply to Addressed Safety Related messages (type 12).
+func DecodeSafetyAcknowledge(payload string) (Acknowledge, error) {
+	data := []byte(payload)
+
+	mType := decodeAisChar(data[0])
+	if mType != 13 {
+		var m Acknowledge
+		return m, errors.New("Message isn't Safety Related Acknowledge (type 13).")
+	}
+
+	return decodeAcknowledge(data)
+}
+
+// decodeAcknowledge decodes the fields common to message types 7 and 13. The message is 72 bits
+// with one acknowledgement and 32 bits longer for each of the other three.
+func decodeAcknowledge(data []byte) (Acknowledge, error) {
+	var m Acknowledge
+
+	if len(data)*6 < 72 {
+		return m, errors.New("Acknowledge is too short, it should carry at least one acknowledgement.")
+	}
+
+	m.Type = decodeAisChar(data[0])
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+
+	// Bits 38-39 are spare
+	for first := 40; first+31 < len(data)*6 && len(m.Acks) < 4; first += 32 {
+		m.Acks = append(m.Acks, Acknowledgement{
+			MMSI:     bitsToInt(first, first+29, data),
+			Sequence: uint8(bitsToInt(first+30, first+31, data)),
+		})
+	}
+
+	return m, nil
+}
+
+// Acknowledges reports whether the acknowledge is the reply of the destination of a Binary
+// Addressed message to it, so that a sent message can be matched to its ACK.
+func (m Acknowledge) Acknowledges(sent BinaryAddressed) bool {
+	if m.Type != 7 || m.MMSI != sent.DestMMSI {
+		return false
+	}
+	for _, ack := range m.Acks {
+		if ack.MMSI == sent.MMSI && ack.Sequence == sent.Sequence {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeAcknowledge(t *testing.T) {
+	header := bitField(0, 2) + bitField(239876000, 30) + bitField(0, 2)
+	acks := bitField(237000000, 30) + bitField(2, 2) + bitField(237111000, 30) + bitField(0, 2)
+
+	cases := []struct {
+		bits string
+		want Acknowledge
+	}{
+		{
+			bitField(7, 6) + header + acks[:32],
+			Acknowledge{Type: 7, MMSI: 239876000, Acks: []Acknowledgement{{237000000, 2}}},
+		},
+		{
+			bitField(7, 6) + header + acks,
+			Acknowledge{Type: 7, MMSI: 239876000, Acks: []Acknowledgement{{237000000, 2}, {237111000, 0}}},
+		},
+		{
+			bitField(13, 6) + header + acks + acks,
+			Acknowledge{Type: 13, MMSI: 239876000, Acks: []Acknowledgement{
+				{237000000, 2}, {237111000, 0}, {237000000, 2}, {237111000, 0},
+			}},
+		},
+	}
+	for _, c := range cases {
+		decode := DecodeBinaryAcknowledge
+		if c.want.Type == 13 {
+			decode = DecodeSafetyAcknowledge
+		}
+		got, err := decode(armor(c.bits))
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeBinaryAcknowledge(payload string), DecodeSafetyAcknowledge(payload string) for %d bits", len(c.bits))
+		}
+	}
+
+	if _, err := DecodeBinaryAcknowledge(armor(bitField(13, 6) + header + acks)); err == nil {
+		t.Errorf("DecodeBinaryAcknowledge(payload string): expected error for wrong type")
+	}
+	if _, err := DecodeBinaryAcknowledge("7000"); err == nil {
+		t.Errorf("DecodeBinaryAcknowledge(payload string): expected error for short message")
+	}
+}
+
+func TestAcknowledges(t *testing.T) {
+	// A type 6 with sequence number 2 and the type 7 its destination replied with
+	sent, _ := DecodeBinaryAddressed(armor(bitField(6, 6) + bitField(0, 2) + bitField(237000000, 30) +
+		bitField(2, 2) + bitField(239876000, 30) + "0" + "0" + bitField(1, 10) + bitField(16, 6) + bitField(42, 13)))
+	ack, _ := DecodeBinaryAcknowledge(armor(bitField(7, 6) + bitField(0, 2) + bitField(239876000, 30) +
+		bitField(0, 2) + bitField(237000000, 30) + bitField(2, 2)))
+
+	otherSequence, otherSource, otherDest := sent, sent, sent
+	otherSequence.Sequence = 1
+	otherSource.MMSI = 237111000
+	otherDest.DestMMSI = 239000000
+	safetyAck := ack
+	safetyAck.Type = 13
+
+	cases := []struct {
+		ack  Acknowledge
+		sent BinaryAddressed
+		want bool
+	}{
+		{ack, sent, true},
+		{ack, otherSequence, false},
+		{ack, otherSource, false},
+		{ack, otherDest, false},
+		{safetyAck, sent, false},
+	}
+	for _, c := range cases {
+		if got := c.ack.Acknowledges(c.sent); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("(Acknowledge) Acknowledges(sent BinaryAddressed) for %v", c.sent)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+)
+
+// An AidToNavigationReport is a decoded AIS Aid-to-Navigation Report (message type 21), sent by
+// or on behalf of buoys, beacons, lights, etc.
+// Please have a look at http://catb.org/gpsd/AIVDM.html
+type AidToNavigationReport struct {
+	Repeat          uint8
+	MMSI            uint32
+	AidType         uint8  // Enumeration at AidTypes
+	Name            string // Name extension included
+	Accuracy        bool   // Position accuracy
+	Lon             float64
+	Lat             float64
+	ToBow           uint16 // Dimension to bow
+	ToStern         uint16 // Dimension to stern
+	ToPort          uint8  // Dimension to port
+	ToStarboard     uint8  // Dimension to starboard
+	EPFD            uint8  // Position Fix Type (enumeration declared at basestationreport.go)
+	Second          uint8  // Timestamp, 60 and above mean not available
+	OffPositionFlag bool   // Off-position indicator as transmitted, see OffPosition
+	RAIM            bool   // RAIM flag
+	Virtual         bool   // Virtual AtoN, there is no physical aid at the position
+	Assigned        bool   // Assigned mode flag
+}
+
+// DecodeAidToNavigationReport decodes [the payload of] an AIS Aid-to-Navigation Report (type 21).
+// The name is 20 characters, followed by an extension of up to 14 more if the message is longer
+// than 272 bits.
+func DecodeAidToNavigationReport(payload string) (AidToNavigationReport, error) {
+	data := []byte(payload)
+	var m AidToNavigationReport
+
+	mType := decodeAisChar(data[0])
+	if mType != 21 {
+		return m, errors.New("Message isn't Aid-to-Navigation Report (type 21).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+
+	m.AidType = uint8(bitsToInt(38, 42, data))
+	m.Name = bitsToString(43, 162, data)
+	m.Accuracy = cbnBool(163, data)
+	m.Lon, m.Lat = cbnCoordinates(164, data)
+
+	m.ToBow = uint16(bitsToInt(219, 227, data))
+	m.ToStern = uint16(bitsToInt(228, 236, data))
+	m.ToPort = uint8(bitsToInt(237, 242, data))
+	m.ToStarboard = uint8(bitsToInt(243, 248, data))
+	m.EPFD = uint8(bitsToInt(249, 252, data))
+
+	m.Second = uint8(bitsToInt(253, 258, data))
+	m.OffPositionFlag = cbnBool(259, data)
+	// Bits 260-267 are reserved for regional applications
+	m.RAIM = cbnBool(268, data)
+	m.Virtual = cbnBool(269, data)
+	m.Assigned = cbnBool(270, data)
+
+	if len(data)*6 > 272 {
+		m.Name += bitsToString(272, 359, data)
+	}
+
+	return m, nil
+}
+
+// OffPosition reports whether the aid is off its charted position. The indicator is only
+// meaningful when the timestamp is a valid UTC second (0-59), otherwise valid is false and the
+// indicator should be ignored, so that an unavailable timestamp doesn't raise a false alert.
+func (m AidToNavigationReport) OffPosition() (off, valid bool) {
+	if m.Second > 59 {
+		return false, false
+	}
+	return m.OffPositionFlag, true
+}
+
+// Position returns the coordinates of the aid, it implements Positioned.
+func (m AidToNavigationReport) Position() (lat, lon float64, ok bool) {
+	return m.Lat, m.Lon, positionAvailable(m.Lon, m.Lat)
+}
+
+// Category returns the category of the aid, AtoNVirtual for virtual aids whatever their type.
+func (m AidToNavigationReport) Category() AtoNCategory {
+	if m.Virtual {
+		return AtoNVirtual
+	}
+	return AidCategory(m.AidType)
+}
+
+// An AtoNCategory groups the Aid-to-Navigation types by the kind of aid, e.g so that chart
+// overlays can pick a symbol.
+type AtoNCategory uint8
+
+// AtoN categories
+const (
+	AtoNUnspecified AtoNCategory = iota
+	AtoNReserved
+	AtoNReferencePoint
+	AtoNRacon
+	AtoNFixedStructure
+	AtoNLight
+	AtoNBeacon
+	AtoNFloatingBuoy
+	AtoNLightVessel
+	AtoNVirtual
+)
+
+// String returns a description of the AtoN category
+func (c AtoNCategory) String() string {
+	switch c {
+	case AtoNReserved:
+		return "reserved"
+	case AtoNReferencePoint:
+		return "reference point"
+	case AtoNRacon:
+		return "RACON"
+	case AtoNFixedStructure:
+		return "fixed structure"
+	case AtoNLight:
+		return "light"
+	case AtoNBeacon:
+		return "beacon"
+	case AtoNFloatingBuoy:
+		return "floating buoy"
+	case AtoNLightVessel:
+		return "light vessel"
+	case AtoNVirtual:
+		return "virtual"
+	}
+	return "unspecified"
+}
+
+// AidCategory returns the category of an Aid-to-Navigation type code. Types 1-19 are fixed aids
+// and 20-31 floating ones. Whether an aid is virtual isn't part of its type, see
+// (AidToNavigationReport) Category.
+func AidCategory(code uint8) AtoNCategory {
+	switch {
+	case code == 0:
+		return AtoNUnspecified
+	case code == 1:
+		return AtoNReferencePoint
+	case code == 2:
+		return AtoNRacon
+	case code == 3:
+		return AtoNFixedStructure
+	case code >= 5 && code <= 8:
+		return AtoNLight
+	case code >= 9 && code <= 19:
+		return AtoNBeacon
+	case code >= 20 && code <= 30:
+		return AtoNFloatingBuoy
+	case code == 31:
+		return AtoNLightVessel
+	}
+	return AtoNReserved
+}
+
+// AidTypeName returns the name of an Aid-to-Navigation type code, "not defined" if the code
+// is out of range.
+func AidTypeName(code uint8) string {
+	if int(code) >= len(AidTypes) {
+		return "not defined"
+	}
+	return AidTypes[code]
+}
+
+// Aid-to-Navigation types
+var AidTypes = [...]string{
+	"Default, type of AtoN not specified", "Reference point", "RACON", "Fixed offshore structure",
+	"Spare", "Light, without sectors", "Light, with sectors", "Leading Light Front",
+	"Leading Light Rear", "Beacon, Cardinal N", "Beacon, Cardinal E", "Beacon, Cardinal S",
+	"Beacon, Cardinal W", "Beacon, Port hand", "Beacon, Starboard hand",
+	"Beacon, Preferred Channel port hand", "Beacon, Preferred Channel starboard hand",
+	"Beacon, Isolated danger", "Beacon, Safe water", "Beacon, Special mark", "Cardinal Mark N",
+	"Cardinal Mark E", "Cardinal Mark S", "Cardinal Mark W", "Port hand Mark", "Starboard hand Mark",
+	"Preferred Channel Port hand", "Preferred Channel Starboard hand", "Isolated danger",
+	"Safe Water", "Special Mark", "Light Vessel / LANBY / Rigs",
+}
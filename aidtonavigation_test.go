@@ -0,0 +1,103 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeAidToNavigationReport(t *testing.T) {
+	// A virtual west cardinal mark off position, with a name extension
+	head := bitField(21, 6) + bitField(0, 2) + bitField(993672086, 30) + bitField(23, 5) +
+		sixBitText("WEST CARDINAL BUOY N", 20) + "1" + bitField(-42300000, 28) + bitField(-19950000, 27) +
+		bitField(1, 9) + bitField(1, 9) + bitField(2, 6) + bitField(2, 6) + bitField(7, 4)
+	tail := "1" + bitField(0, 8) + "0" + "1" + "0" + "0"
+	extended := armor(head + bitField(42, 6) + tail + sixBitText("O 7", 3))
+	// Same aid, without extension and with the timestamp not available
+	short := armor(head + bitField(61, 6) + tail)
+
+	cases := []struct {
+		payload string
+		want    AidToNavigationReport
+		off     bool
+		valid   bool
+	}{
+		{
+			extended,
+			AidToNavigationReport{
+				MMSI: 993672086, AidType: 23, Name: "WEST CARDINAL BUOY NO 7", Accuracy: true, Lon: -70.5, Lat: -33.25,
+				ToBow: 1, ToStern: 1, ToPort: 2, ToStarboard: 2, EPFD: 7, Second: 42, OffPositionFlag: true, Virtual: true,
+			},
+			true, true,
+		},
+		{
+			short,
+			AidToNavigationReport{
+				MMSI: 993672086, AidType: 23, Name: "WEST CARDINAL BUOY N", Accuracy: true, Lon: -70.5, Lat: -33.25,
+				ToBow: 1, ToStern: 1, ToPort: 2, ToStarboard: 2, EPFD: 7, Second: 61, OffPositionFlag: true, Virtual: true,
+			},
+			false, false,
+		},
+	}
+	for _, c := range cases {
+		got, err := DecodeAidToNavigationReport(c.payload)
+		off, valid := got.OffPosition()
+		if err != nil || got != c.want || off != c.off || valid != c.valid {
+			fmt.Println("Got : ", got, off, valid, err)
+			fmt.Println("Want: ", c.want, c.off, c.valid)
+			t.Errorf("DecodeAidToNavigationReport(payload string)")
+		}
+	}
+
+	if _, err := DecodeAidToNavigationReport("38u<a<?PAA2>P:WfuAO9PW<P0PuQ"); err == nil {
+		t.Errorf("DecodeAidToNavigationReport(payload string) accepted a type 3 message")
+	}
+}
+
+func TestAidCategory(t *testing.T) {
+	cases := []struct {
+		code uint8
+		name string
+		want AtoNCategory
+	}{
+		{0, "Default, type of AtoN not specified", AtoNUnspecified},
+		{2, "RACON", AtoNRacon},
+		{4, "Spare", AtoNReserved},
+		{6, "Light, with sectors", AtoNLight},
+		{12, "Beacon, Cardinal W", AtoNBeacon},
+		{23, "Cardinal Mark W", AtoNFloatingBuoy},
+		{31, "Light Vessel / LANBY / Rigs", AtoNLightVessel},
+		{32, "not defined", AtoNReserved},
+	}
+	for _, c := range cases {
+		name, got := AidTypeName(c.code), AidCategory(c.code)
+		if name != c.name || got != c.want {
+			fmt.Println("Got : ", name, got)
+			fmt.Println("Want: ", c.name, c.want)
+			t.Errorf("AidTypeName(code uint8), AidCategory(code uint8) for %d", c.code)
+		}
+	}
+
+	virtual := AidToNavigationReport{AidType: 23, Virtual: true}
+	if got := virtual.Category(); got != AtoNVirtual {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", AtoNVirtual)
+		t.Errorf("(AidToNavigationReport) Category()")
+	}
+}
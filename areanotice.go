@@ -0,0 +1,253 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// An AreaNotice is a decoded Area Notice binary message (DAC 1, FI 22).
+// It describes one area with one or more sub-areas (shapes) and what is happening there
+// (e.g restricted area, marine mammals, etc.) for a certain time window.
+// Please have a look at http://catb.org/gpsd/AIVDM.html and IMO SN.1/Circ.289
+type AreaNotice struct {
+	LinkageID uint16    // Message linkage ID
+	Notice    uint8     // Notice description (enumeration at AreaNoticeDescriptions)
+	Start     time.Time // UTC start time. Does not include year, like the ETA of type 5
+	Duration  uint32    // Minutes, 262143 means undefined
+	SubAreas  []AreaNoticeSubArea
+}
+
+// An AreaNoticeSubArea is one of the shapes that compose the area of an AreaNotice.
+// Only the fields relevant to its Shape are set. Distances are in meters, scale factor applied.
+type AreaNoticeSubArea struct {
+	Shape       uint8   // Area shape (enumeration at AreaNoticeShapes)
+	Lon         float64 // Circle, rectangle and sector (sc 1/1000 min)
+	Lat         float64 // Circle, rectangle and sector (sc 1/1000 min)
+	Precision   uint8   // Decimal places of precision of the coordinates
+	Radius      uint32  // Circle and sector
+	East        uint32  // Rectangle dimension to the east
+	North       uint32  // Rectangle dimension to the north
+	Orientation uint16  // Rectangle orientation, degrees
+	LeftBound   uint16  // Sector left boundary, degrees
+	RightBound  uint16  // Sector right boundary, degrees
+	Points      []AreaNoticePoint
+	Text        string
+}
+
+// An AreaNoticePoint is a point of a polyline or polygon sub-area, relative to the previous point
+// (or the position of the previous sub-area for the first point).
+type AreaNoticePoint struct {
+	Angle    float32 // Degrees
+	Distance uint32  // Meters
+}
+
+// Area notice sub-area shapes
+var AreaNoticeShapes = [...]string{
+	"Circle or point", "Rectangle", "Sector", "Polyline", "Polygon", "Text",
+	"shape reserved", "shape reserved",
+}
+
+// Sub-area shape codes
+const (
+	AreaNoticeCircle = iota
+	AreaNoticeRectangle
+	AreaNoticeSector
+	AreaNoticePolyline
+	AreaNoticePolygon
+	AreaNoticeText
+)
+
+// DecodeAreaNotice decodes the application specific data of an Area Notice (DAC 1, FI 22).
+// The data should start at bit 0 (see BinaryBroadcast.BinaryData). Each sub-area is 87 bits,
+// so the number of sub-areas is derived from the data length.
+func DecodeAreaNotice(data []byte) (AreaNotice, error) {
+	var m AreaNotice
+
+	if len(data)*6 < 55+87 {
+		return m, errors.New("Area Notice is too short, it should carry at least one sub-area.")
+	}
+
+	m.LinkageID = uint16(bitsToInt(0, 9, data))
+	m.Notice = uint8(bitsToInt(10, 16, data))
+
+	month := uint8(bitsToInt(17, 20, data))
+	day := uint8(bitsToInt(21, 25, data))
+	hour := uint8(bitsToInt(26, 30, data))
+	minute := uint8(bitsToInt(31, 36, data))
+	timeString := fmt.Sprintf("%d/%d %d:%d", month, day, hour, minute)
+	m.Start, _ = time.Parse("1/2 15:4", timeString)
+
+	m.Duration = bitsToInt(37, 54, data)
+
+	for first := 55; first+86 < len(data)*6; first += 87 {
+		m.SubAreas = append(m.SubAreas, decodeAreaNoticeSubArea(first, data))
+	}
+
+	return m, nil
+}
+
+// decodeAreaNoticeSubArea decodes the 87 bit sub-area starting at bit first.
+func decodeAreaNoticeSubArea(first int, data []byte) AreaNoticeSubArea {
+	var s AreaNoticeSubArea
+
+	s.Shape = uint8(bitsToInt(first, first+2, data))
+	if s.Shape == AreaNoticeText {
+		s.Text = bitsToString(first+3, first+86, data)
+		return s
+	}
+
+	// The scale factor multiplies all distances: 1, 10, 100 or 1000
+	scale := uint32(1)
+	for i := uint32(0); i < bitsToInt(first+3, first+4, data); i++ {
+		scale *= 10
+	}
+
+	switch s.Shape {
+	case AreaNoticeCircle, AreaNoticeRectangle, AreaNoticeSector:
+		s.Lon, _ = decodeLongitude(int64(bitsToSignedInt(first+5, first+29, data)), MinuteThousandths)
+		s.Lat, _ = decodeLatitude(int64(bitsToSignedInt(first+30, first+53, data)), MinuteThousandths)
+		s.Precision = uint8(bitsToInt(first+54, first+56, data))
+		switch s.Shape {
+		case AreaNoticeCircle:
+			s.Radius = scale * bitsToInt(first+57, first+68, data)
+		case AreaNoticeRectangle:
+			s.East = scale * bitsToInt(first+57, first+64, data)
+			s.North = scale * bitsToInt(first+65, first+72, data)
+			s.Orientation = uint16(bitsToInt(first+73, first+81, data))
+		case AreaNoticeSector:
+			s.Radius = scale * bitsToInt(first+57, first+68, data)
+			s.LeftBound = uint16(bitsToInt(first+69, first+77, data))
+			s.RightBound = uint16(bitsToInt(first+78, first+86, data))
+		}
+	case AreaNoticePolyline, AreaNoticePolygon:
+		// Up to four points, an angle of 720 (360°) means there are no more points
+		for p := first + 5; p < first+85; p += 20 {
+			angle := bitsToInt(p, p+9, data)
+			if angle == 720 {
+				break
+			}
+			s.Points = append(s.Points, AreaNoticePoint{
+				Angle:    float32(angle) / 2,
+				Distance: scale * bitsToInt(p+10, p+19, data),
+			})
+		}
+	}
+
+	return s
+}
+
+// Area notice description codes. Codes that are not listed are reserved for future use.
+var AreaNoticeDescriptions = map[int]string{
+	0:   "Caution Area: Marine mammals habitat",
+	1:   "Caution Area: Marine mammals in area - reduce speed",
+	2:   "Caution Area: Marine mammals in area - stay clear",
+	3:   "Caution Area: Marine mammals in area - report sightings",
+	4:   "Caution Area: Protected habitat - reduce speed",
+	5:   "Caution Area: Protected habitat - stay clear",
+	6:   "Caution Area: Protected habitat - no fishing or anchoring",
+	7:   "Caution Area: Derelicts (drifting objects)",
+	8:   "Caution Area: Traffic congestion",
+	9:   "Caution Area: Marine event",
+	10:  "Caution Area: Divers down",
+	11:  "Caution Area: Swim area",
+	12:  "Caution Area: Dredge operations",
+	13:  "Caution Area: Survey operations",
+	14:  "Caution Area: Underwater operation",
+	15:  "Caution Area: Seaplane operations",
+	16:  "Caution Area: Fishery - nets in water",
+	17:  "Caution Area: Cluster of fishing vessels",
+	18:  "Caution Area: Fairway closed",
+	19:  "Caution Area: Harbour closed",
+	20:  "Caution Area: Risk (define in associated text field)",
+	21:  "Caution Area: Underwater vehicle operation",
+	23:  "Environmental Caution Area: Storm front (line squall)",
+	24:  "Environmental Caution Area: Hazardous sea ice",
+	25:  "Environmental Caution Area: Storm warning (storm cell or line of storms)",
+	26:  "Environmental Caution Area: High wind",
+	27:  "Environmental Caution Area: High waves",
+	28:  "Environmental Caution Area: Restricted visibility (fog, rain, etc.)",
+	29:  "Environmental Caution Area: Strong currents",
+	30:  "Environmental Caution Area: Heavy icing",
+	31:  "Restricted Area: Fishing prohibited",
+	32:  "Restricted Area: No anchoring",
+	33:  "Restricted Area: Entry approval required prior to transit",
+	34:  "Restricted Area: Entry prohibited",
+	35:  "Restricted Area: Active military OPAREA",
+	36:  "Restricted Area: Firing - danger area",
+	37:  "Restricted Area: Drifting Mines",
+	39:  "Anchorage Area: Anchorage open",
+	40:  "Anchorage Area: Anchorage closed",
+	41:  "Anchorage Area: Anchoring prohibited",
+	42:  "Anchorage Area: Deep draft anchorage",
+	43:  "Anchorage Area: Shallow draft anchorage",
+	44:  "Anchorage Area: Vessel transfer operations",
+	56:  "Security Alert - Level 1",
+	57:  "Security Alert - Level 2",
+	58:  "Security Alert - Level 3",
+	64:  "Distress Area: Vessel disabled and adrift",
+	65:  "Distress Area: Vessel sinking",
+	66:  "Distress Area: Vessel abandoning ship",
+	67:  "Distress Area: Vessel requests medical assistance",
+	68:  "Distress Area: Vessel flooding",
+	69:  "Distress Area: Vessel fire/explosion",
+	70:  "Distress Area: Vessel grounding",
+	71:  "Distress Area: Vessel collision",
+	72:  "Distress Area: Vessel listing/capsizing",
+	73:  "Distress Area: Vessel under assault",
+	74:  "Distress Area: Person overboard",
+	75:  "Distress Area: SAR area",
+	76:  "Distress Area: Pollution response area",
+	80:  "Instruction: Contact VTS at this point/juncture",
+	81:  "Instruction: Contact Port Administration at this point/juncture",
+	82:  "Instruction: Do not proceed beyond this point/juncture",
+	83:  "Instruction: Await instructions prior to proceeding beyond this point/juncture",
+	84:  "Proceed to this location - await instructions",
+	85:  "Clearance granted - proceed to berth",
+	88:  "Information: Pilot boarding position",
+	89:  "Information: Icebreaker waiting area",
+	90:  "Information: Places of refuge",
+	91:  "Information: Position of icebreakers",
+	92:  "Information: Location of response units",
+	93:  "VTS active target",
+	94:  "Rogue or suspicious vessel",
+	95:  "Vessel requesting non-distress assistance",
+	96:  "Chart Feature: Sunken vessel",
+	97:  "Chart Feature: Submerged object",
+	98:  "Chart Feature: Semi-submerged object",
+	99:  "Chart Feature: Shoal area",
+	100: "Chart Feature: Shoal area due north",
+	101: "Chart Feature: Shoal area due east",
+	102: "Chart Feature: Shoal area due south",
+	103: "Chart Feature: Shoal area due west",
+	104: "Chart Feature: Channel obstruction",
+	105: "Chart Feature: Reduced vertical clearance",
+	106: "Chart Feature: Bridge closed",
+	107: "Chart Feature: Bridge partially open",
+	108: "Chart Feature: Bridge fully open",
+	112: "Report from ship: Icing info",
+	114: "Report from ship: Miscellaneous information - define in associated text field",
+	120: "Route: Recommended route",
+	121: "Route: Alternative route",
+	122: "Route: Recommended route through ice",
+	125: "Other - Define in associated text field",
+	126: "Cancellation - cancel area as notified by Message Linkage ID",
+	127: "Undefined (default)",
+}
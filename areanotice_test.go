@@ -0,0 +1,80 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeAreaNotice(t *testing.T) {
+	start, _ := time.Parse("1/2 15:4", "6/21 12:30")
+
+	header := bitField(5, 10) + bitField(34, 7) + bitField(6, 4) + bitField(21, 5) +
+		bitField(12, 5) + bitField(30, 6) + bitField(120, 18)
+	// A circle of 2km (scale 10) at 23°30'E 37°30'S
+	circle := bitField(0, 3) + bitField(1, 2) + bitField(23*60000+30000, 25) +
+		bitField(-(37*60000+30000), 24) + bitField(4, 3) + bitField(200, 12) + bitField(0, 18)
+	// A polygon with two points, the rest are marked not available
+	polygon := bitField(4, 3) + bitField(0, 2) + bitField(90, 10) + bitField(500, 10) +
+		bitField(181, 10) + bitField(250, 10) + bitField(720, 10) + bitField(0, 10) +
+		bitField(720, 10) + bitField(0, 10) + bitField(0, 2)
+	// Text "ABC", the rest is @ padding
+	text := bitField(5, 3) + bitField(1, 6) + bitField(2, 6) + bitField(3, 6) + strings.Repeat("0", 66)
+
+	cases := []struct {
+		bits string
+		want AreaNotice
+	}{
+		{
+			header + circle,
+			AreaNotice{LinkageID: 5, Notice: 34, Start: start, Duration: 120, SubAreas: []AreaNoticeSubArea{
+				{Shape: AreaNoticeCircle, Lon: 23.5, Lat: -37.5, Precision: 4, Radius: 2000},
+			}},
+		},
+		{
+			header + circle + polygon + text,
+			AreaNotice{LinkageID: 5, Notice: 34, Start: start, Duration: 120, SubAreas: []AreaNoticeSubArea{
+				{Shape: AreaNoticeCircle, Lon: 23.5, Lat: -37.5, Precision: 4, Radius: 2000},
+				{Shape: AreaNoticePolygon, Points: []AreaNoticePoint{{45, 500}, {90.5, 250}}},
+				{Shape: AreaNoticeText, Text: "ABC"},
+			}},
+		},
+	}
+	for _, c := range cases {
+		got, err := DecodeAreaNotice([]byte(armor(c.bits)))
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeAreaNotice(data []byte)")
+		}
+	}
+
+	// It should be reachable through the binary decoders too
+	got, err := DecodeBinaryData(1, 22, []byte(armor(header+circle)))
+	if _, ok := got.(AreaNotice); !ok || err != nil {
+		t.Errorf("DecodeBinaryData(dac uint16, fi uint8, data []byte): DAC 1 FI 22 isn't an AreaNotice")
+	}
+
+	if _, err := DecodeAreaNotice([]byte(armor(header))); err == nil {
+		t.Errorf("DecodeAreaNotice(data []byte): expected error for notice without sub-areas")
+	}
+}
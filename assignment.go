@@ -0,0 +1,78 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+	"time"
+)
+
+// An AssignmentModeCommand is a decoded AIS Assignment Mode Command (message type 16). A base
+// station assigns a reporting schedule to one or two stations.
+// Please have a look at http://catb.org/gpsd/AIVDM.html and ITU-R M.1371-5, 3.16.
+type AssignmentModeCommand struct {
+	Repeat      uint8
+	MMSI        uint32
+	Assignments []AssignmentMode // One or two
+}
+
+// An AssignmentMode is the schedule assigned to a station by an AssignmentModeCommand.
+type AssignmentMode struct {
+	DestMMSI  uint32
+	Offset    uint16 // Slot offset of the first assigned slot, or reports per 10 minutes if Increment is 0
+	Increment uint16 // Slots to the next assigned slot, 0 means Offset is a reporting rate
+}
+
+// DecodeAssignmentModeCommand decodes [the payload of] an AIS Assignment Mode Command (type 16).
+// The message assigns one (96 bits) or two (144 bits) stations.
+func DecodeAssignmentModeCommand(payload string) (AssignmentModeCommand, error) {
+	data := []byte(payload)
+	var m AssignmentModeCommand
+
+	mType := decodeAisChar(data[0])
+	if mType != 16 {
+		return m, errors.New("Message isn't Assignment Mode Command (type 16).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+
+	for first := 40; first+51 < len(data)*6 && len(m.Assignments) < 2; first += 52 {
+		m.Assignments = append(m.Assignments, AssignmentMode{
+			DestMMSI:  bitsToInt(first, first+29, data),
+			Offset:    uint16(bitsToInt(first+30, first+41, data)),
+			Increment: uint16(bitsToInt(first+42, first+51, data)),
+		})
+	}
+
+	return m, nil
+}
+
+// ReportingInterval returns the approximate interval between the reports of the assigned station.
+// With an increment, the station reports every Increment slots of the frame (2250 slots make a
+// minute). With no increment, Offset is the number of reports per 10 minutes. It returns false if
+// neither is set, which means the station reverts to its autonomous reporting rate.
+func (a AssignmentMode) ReportingInterval() (time.Duration, bool) {
+	switch {
+	case a.Increment > 0:
+		return time.Duration(a.Increment) * time.Minute / FrameSlots, true
+	case a.Offset > 0:
+		return 10 * time.Minute / time.Duration(a.Offset), true
+	}
+	return 0, false
+}
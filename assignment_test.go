@@ -0,0 +1,79 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeAssignmentModeCommand(t *testing.T) {
+	two := bitField(16, 6) + bitField(0, 2) + bitField(2579999, 30) + bitField(0, 2) +
+		bitField(257123000, 30) + bitField(20, 12) + bitField(750, 10) +
+		bitField(257456000, 30) + bitField(30, 12) + bitField(0, 10)
+	cases := []struct {
+		payload string
+		want    AssignmentModeCommand
+	}{
+		{
+			"@01uEO@mMk7P<P00",
+			AssignmentModeCommand{Repeat: 0, MMSI: 2053501, Assignments: []AssignmentMode{
+				{DestMMSI: 224251000, Offset: 200, Increment: 0},
+			}},
+		},
+		{
+			armor(two),
+			AssignmentModeCommand{Repeat: 0, MMSI: 2579999, Assignments: []AssignmentMode{
+				{DestMMSI: 257123000, Offset: 20, Increment: 750},
+				{DestMMSI: 257456000, Offset: 30, Increment: 0},
+			}},
+		},
+	}
+	for _, c := range cases {
+		got, _ := DecodeAssignmentModeCommand(c.payload)
+		if !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeAssignmentModeCommand(payload string)")
+		}
+	}
+}
+
+func TestAssignmentModeReportingInterval(t *testing.T) {
+	cases := []struct {
+		assignment AssignmentMode
+		interval   time.Duration
+		ok         bool
+	}{
+		{AssignmentMode{Offset: 20, Increment: 750}, 20 * time.Second, true}, // Three times per frame
+		{AssignmentMode{Offset: 0, Increment: 225}, 6 * time.Second, true},
+		{AssignmentMode{Offset: 30, Increment: 0}, 20 * time.Second, true}, // 30 reports per 10 minutes
+		{AssignmentMode{Offset: 200, Increment: 0}, 3 * time.Second, true},
+		{AssignmentMode{Offset: 0, Increment: 0}, 0, false},
+	}
+	for _, c := range cases {
+		interval, ok := c.assignment.ReportingInterval()
+		if interval != c.interval || ok != c.ok {
+			fmt.Println("Got : ", interval, ok)
+			fmt.Println("Want: ", c.interval, c.ok)
+			t.Errorf("(AssignmentMode) ReportingInterval()")
+		}
+	}
+}
@@ -24,16 +24,26 @@ import (
 )
 
 // A BaseStationReport is a decoded AIS base station report (message type 4)
+// or UTC/Date response (message type 11), which share the same layout.
 type BaseStationReport struct {
-	Repeat   uint8
-	MMSI     uint32
-	Time     time.Time
-	Accuracy bool
-	Lon      float64
-	Lat      float64
-	EPFD     uint8 // Enum type
-	RAIM     bool
-	Radio    uint32
+	Type      uint8 // 4 or 11
+	Response  bool  // true for a UTC/date response to an inquiry (type 11), false for an autonomous report
+	Repeat    uint8
+	MMSI      uint32
+	Time      time.Time
+	Accuracy  bool
+	Lon       float64
+	Lat       float64
+	EPFD      uint8 // Enum type
+	LongRange bool  // Transmission control for long-range broadcast message (type 27)
+	RAIM      bool
+	Radio     uint32
+	CommState CommState // Always SOTDMA
+}
+
+// Position returns the coordinates of the base station, it implements Positioned.
+func (m BaseStationReport) Position() (lat, lon float64, ok bool) {
+	return m.Lat, m.Lon, positionAvailable(m.Lon, m.Lat)
 }
 
 // EPFD Fix Codes
@@ -47,13 +57,38 @@ var EpfdFixTypes = [...]string{
 // DecodeBaseStationReport decodes the payload of a Type 4 AIS message
 func DecodeBaseStationReport(payload string) (BaseStationReport, error) {
 	data := []byte(payload)
-	var m BaseStationReport
 
 	mType := decodeAisChar(data[0])
 	if mType != 4 {
+		var m BaseStationReport
 		return m, errors.New("Message isn't Base Station Report (type 4).")
 	}
 
+	return decodeBaseStation(payload), nil
+}
+
+// DecodeUTCDateResponse decodes the payload of a Type 11 AIS message. It is the reply of a
+// station to a UTC/date inquiry (type 10) and has the same layout as a base station report.
+func DecodeUTCDateResponse(payload string) (BaseStationReport, error) {
+	data := []byte(payload)
+
+	mType := decodeAisChar(data[0])
+	if mType != 11 {
+		var m BaseStationReport
+		return m, errors.New("Message isn't UTC/Date Response (type 11).")
+	}
+
+	return decodeBaseStation(payload), nil
+}
+
+// decodeBaseStation decodes the fields common to message types 4 and 11.
+func decodeBaseStation(payload string) BaseStationReport {
+	data := []byte(payload)
+	var m BaseStationReport
+
+	m.Type = decodeAisChar(data[0])
+	m.Response = m.Type == 11
+
 	//m.Repeat = decodeAisChar(data[1]) >> 4
 	m.Repeat = uint8(bitsToInt(6, 7, data))
 
@@ -70,10 +105,18 @@ func DecodeBaseStationReport(payload string) (BaseStationReport, error) {
 
 	m.EPFD = uint8(bitsToInt(134, 137, data))
 
+	// Set, the base station asks the Class A stations in its coverage area to transmit long-range
+	// broadcasts (type 27), which they don't by default
+	m.LongRange = cbnBool(138, data)
+
+	// Bits 139-147 are spare
+
 	m.RAIM = cbnBool(148, data)
 
 	m.Radio = bitsToInt(149, 167, data)
-	return m, nil
+	// Both types use SOTDMA. Type 11 is sent in reply to an inquiry but keeps the SOTDMA format.
+	m.CommState = decodeCommState(149, false, data)
+	return m
 }
 
 // GetReferenceTime takes [the payload of] an AIS Base Station message (type 4)
@@ -26,6 +26,9 @@ import (
 func TestDecodeBaseStationReport(t *testing.T) {
 	caseTime1, _ := time.Parse("2006/1/2 15:4:5", "2015/2/4 0:33:51")
 	caseTime2, _ := time.Parse("2006/1/2 15:4:5", "2015/2/4 0:33:50")
+	// The first sentence, asking for long-range broadcasts. The spare bits after the flag are set too.
+	bits := payloadBits("402R3KiutR0Qk156V4QQTOA00<0;")
+	longRange := armor(bits[:138] + "1111111111" + bits[148:])
 	cases := []struct {
 		payload string
 		want    BaseStationReport
@@ -33,15 +36,25 @@ func TestDecodeBaseStationReport(t *testing.T) {
 		{
 			"402R3KiutR0Qk156V4QQTOA00<0;",
 			BaseStationReport{
-				Repeat: 0, MMSI: 2655087, Time: caseTime1, Accuracy: false, Lon: 15.09579,
+				Type: 4, Repeat: 0, MMSI: 2655087, Time: caseTime1, Accuracy: false, Lon: 15.09579,
 				Lat: 58.588368333333335, EPFD: 1, RAIM: false, Radio: 49163,
+				CommState: CommState{SlotTimeout: 3, ReceivedStations: 11},
 			},
 		},
 		{
 			"4025boiutR0Qj0qgK<OodKW00@N1",
 			BaseStationReport{
-				Repeat: 0, MMSI: 2190047, Time: caseTime2, Accuracy: false, Lon: 12.613716666666667,
+				Type: 4, Repeat: 0, MMSI: 2190047, Time: caseTime2, Accuracy: false, Lon: 12.613716666666667,
 				Lat: 55.69725, EPFD: 7, RAIM: false, Radio: 67457,
+				CommState: CommState{SlotTimeout: 4, SlotNumber: 1921},
+			},
+		},
+		{
+			longRange,
+			BaseStationReport{
+				Type: 4, Repeat: 0, MMSI: 2655087, Time: caseTime1, Accuracy: false, Lon: 15.09579,
+				Lat: 58.588368333333335, EPFD: 1, LongRange: true, RAIM: false, Radio: 49163,
+				CommState: CommState{SlotTimeout: 3, ReceivedStations: 11},
 			},
 		},
 	}
@@ -55,6 +68,52 @@ func TestDecodeBaseStationReport(t *testing.T) {
 	}
 }
 
+func TestDecodeUTCDateResponse(t *testing.T) {
+	caseTime, _ := time.Parse("2006/1/2 15:4:5", "2009/5/22 2:22:40")
+	want := BaseStationReport{
+		Type: 11, Response: true, Repeat: 0, MMSI: 304137000, Time: caseTime, Accuracy: true,
+		Lon: -94.40768333333334, Lat: 28.409116666666666, EPFD: 1, LongRange: false, RAIM: false, Radio: 0,
+	}
+	payload := ";4R33:1uUK2F`q?mOt@@GoQ00000"
+
+	got, _ := DecodeUTCDateResponse(payload)
+	if got != want {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeUTCDateResponse(payload string)")
+	}
+
+	// The real world sentence has all flags clear, set the long range and RAIM flags
+	bits := payloadBits(payload)
+	bits = bits[:138] + "1" + bits[139:148] + "1" + bits[149:]
+	want.LongRange, want.RAIM = true, true
+	got, _ = DecodeUTCDateResponse(armor(bits))
+	if got != want {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeUTCDateResponse(payload string)")
+	}
+
+	if _, err := DecodeUTCDateResponse("402R3KiutR0Qk156V4QQTOA00<0;"); err == nil {
+		t.Errorf("DecodeUTCDateResponse(payload string)")
+	}
+}
+
+func TestBaseStationReportResponse(t *testing.T) {
+	report, _ := DecodeBaseStationReport("402R3KiutR0Qk156V4QQTOA00<0;")
+	response, _ := DecodeUTCDateResponse(";4R33:1uUK2F`q?mOt@@GoQ00000")
+	if report.Type != 4 || report.Response || response.Type != 11 || !response.Response {
+		fmt.Println("Got : ", report.Type, report.Response, response.Type, response.Response)
+		fmt.Println("Want: ", 4, false, 11, true)
+		t.Errorf("DecodeBaseStationReport(payload string), DecodeUTCDateResponse(payload string)")
+	}
+
+	// Each decoder accepts only its own type
+	if _, err := DecodeBaseStationReport(";4R33:1uUK2F`q?mOt@@GoQ00000"); err == nil {
+		t.Errorf("DecodeBaseStationReport(payload string)")
+	}
+}
+
 func BenchmarkDecodeBaseStationReport(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		DecodeBaseStationReport("402R3KiutR0Qk156V4QQTOA00<0;")
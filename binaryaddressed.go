@@ -0,0 +1,66 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+)
+
+// BinaryAddressed is a Type 6 message
+type BinaryAddressed struct {
+	Repeat     uint8
+	MMSI       uint32
+	Sequence   uint8 // Sequence number
+	DestMMSI   uint32
+	Retransmit bool
+	DAC        uint16
+	FID        uint8
+	Data       string
+}
+
+// DecodeBinaryAddressed decodes [the payload of] an AIS Binary Addressed message (Type 6) but not its binary payload
+func DecodeBinaryAddressed(payload string) (BinaryAddressed, error) {
+	data := []byte(payload)
+	var m BinaryAddressed
+
+	mType := decodeAisChar(data[0])
+	if mType != 6 {
+		return m, errors.New("Message isn't Binary Addressed (type 6).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+
+	m.MMSI = bitsToInt(8, 37, data)
+
+	m.Sequence = uint8(bitsToInt(38, 39, data))
+	m.DestMMSI = bitsToInt(40, 69, data)
+	m.Retransmit = cbnBool(70, data)
+
+	m.DAC = uint16(bitsToInt(72, 81, data))
+	m.FID = uint8(bitsToInt(82, 87, data))
+
+	m.Data = payload // Data start at bit 88, as with Binary Broadcast we keep the whole payload
+
+	return m, nil
+}
+
+// BinaryData returns the application specific data of a Binary Addressed message (bits 88 onwards),
+// re-armored so that they start at bit 0. This is the input the binary message decoders expect.
+func (m BinaryAddressed) BinaryData() []byte {
+	return shiftPayload(88, []byte(m.Data))
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeBinaryAddressed(t *testing.T) {
+	header := bitField(6, 6) + bitField(0, 2) + bitField(237000000, 30) + bitField(2, 2) +
+		bitField(239876000, 30) + bitField(1, 1) + bitField(0, 1) + bitField(1, 10) + bitField(16, 6)
+	payload := armor(header + bitField(42, 13) + bitField(0, 3))
+	want := BinaryAddressed{Repeat: 0, MMSI: 237000000, Sequence: 2, DestMMSI: 239876000,
+		Retransmit: true, DAC: 1, FID: 16, Data: payload}
+
+	got, _ := DecodeBinaryAddressed(payload)
+	if got != want {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeBinaryAddressed(payload string)")
+	}
+
+	pob, err := DecodeBinaryData(got.DAC, got.FID, got.BinaryData())
+	if err != nil || pob != (PersonsOnBoard{42}) {
+		fmt.Println("Got : ", pob, err)
+		fmt.Println("Want: ", PersonsOnBoard{42})
+		t.Errorf("DecodeBinaryData(dac uint16, fi uint8, data []byte)")
+	}
+}
@@ -19,6 +19,7 @@ package aislib
 
 import (
 	"errors"
+	"fmt"
 )
 
 // BinaryBroadcast is a Type 8 message
@@ -52,12 +53,51 @@ func DecodeBinaryBroadcast(payload string) (BinaryBroadcast, error) {
 	return m, nil
 }
 
+// BinaryData returns the application specific data of a Binary Broadcast message (bits 56 onwards),
+// re-armored so that they start at bit 0. This is the input the binary message decoders expect.
+func (m BinaryBroadcast) BinaryData() []byte {
+	return shiftPayload(56, []byte(m.Data))
+}
+
+// binaryDecoders are the decoding functions of the application specific messages we understand,
+// indexed by DAC and FI (like BinaryBroadcastType).
+var binaryDecoders = map[int]map[int]func(data []byte) (interface{}, error){
+	1: {
+		11: func(data []byte) (interface{}, error) { return decodeMetHydroFI11(data) },
+		12: func(data []byte) (interface{}, error) { return DecodeDangerousCargo(data) },
+		16: func(data []byte) (interface{}, error) { return DecodePersonsOnBoard(data) },
+		17: func(data []byte) (interface{}, error) { return DecodeVTSTargets(data) },
+		22: func(data []byte) (interface{}, error) { return DecodeAreaNotice(data) },
+		24: func(data []byte) (interface{}, error) { return DecodeExtendedStaticVoyage(data) },
+		26: func(data []byte) (interface{}, error) { return DecodeEnvironmental(data) },
+		31: func(data []byte) (interface{}, error) { return DecodeMetHydro(data) },
+		40: func(data []byte) (interface{}, error) { return DecodePersonsOnBoard(data) },
+	},
+	200: {
+		10: func(data []byte) (interface{}, error) { return DecodeInlandStaticVoyage(data) },
+		21: func(data []byte) (interface{}, error) { return DecodeInlandETA(data) },
+		22: func(data []byte) (interface{}, error) { return DecodeInlandRTA(data) },
+	},
+}
+
+// DecodeBinaryData decodes the application specific data of a binary message if we have a
+// decoder for its DAC and FI. Data should start at bit 0, as returned from BinaryData.
+// The result is one of the application specific types (e.g AreaNotice), so use a type switch.
+func DecodeBinaryData(dac uint16, fi uint8, data []byte) (interface{}, error) {
+	decoder, ok := binaryDecoders[int(dac)][int(fi)]
+	if !ok {
+		return nil, fmt.Errorf("no decoder for binary message DAC %d, FI %d", dac, fi)
+	}
+	return decoder(data)
+}
+
 // Some Binary Broadcast types. The list isn't complete but I haven't searched for a better source
 var BinaryBroadcastType = map[int]map[int]string{
 	1: {
 		11: "Meteorological/Hydrogological Data",
 		13: "Fairway closed",
 		15: "Extended ship and voyage",
+		16: "Number of persons on board",
 		17: "VTS-Generated/Synthetic targets",
 		19: "Marine traffic signals",
 		21: "Weather observation from ship",
@@ -67,9 +107,12 @@ var BinaryBroadcastType = map[int]map[int]string{
 		27: "Route info broadcast",
 		29: "Text description broadcast",
 		31: "Meteorological and Hydrological",
+		40: "Number of persons on board (broadcast)",
 	},
 	200: {
 		10: "Ship static and voyage related data",
+		21: "ETA at lock/bridge/terminal",
+		22: "RTA at lock/bridge/terminal",
 		23: "EMMA warning report",
 		24: "Water levels",
 		40: "Signal status",
@@ -0,0 +1,81 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeBinaryBroadcast(t *testing.T) {
+	payload := "85Mwom1KfI?GR<NgcvM1Hg<P2FaGjRN<S22j;WN:IDle3f5Qsq6=620c;<gvsa8P?;j>Nl0oKaCLIdeFlr<Gh@Jc95:i>c0"
+	want := BinaryBroadcast{Repeat: 0, MMSI: 366999508, DAC: 366, FID: 57, Data: payload}
+
+	got, _ := DecodeBinaryBroadcast(payload)
+	if got != want {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeBinaryBroadcast(payload string)")
+	}
+
+	// The binary data should be the bits after the FI, starting at bit 0
+	data := got.BinaryData()
+	for i := 0; i+56 < len(payload)*6; i++ {
+		if bitsToInt(i, i, data) != bitsToInt(i+56, i+56, []byte(payload)) {
+			t.Errorf("(BinaryBroadcast) BinaryData(): bit %d differs", i)
+			break
+		}
+	}
+}
+
+// A one bit error in the header shifts the whole application data. The DAC, FI and the first data
+// bits have their edge bits set and the spare bits around them are set, so that an offset by one
+// either way changes the result.
+func TestBinaryHeaderOffsets(t *testing.T) {
+	dac, fi, first := bitField(513, 10), bitField(33, 6), "101"
+
+	addressed := armor(bitField(6, 6) + bitField(0, 2) + bitField(237000000, 30) + bitField(0, 2) +
+		bitField(239876000, 30) + "0" + "1" + dac + fi + first) // Retransmit 0, spare 1
+	broadcast := armor(bitField(8, 6) + bitField(0, 2) + bitField(366999508, 30) + "11" + dac + fi + first)
+
+	a, _ := DecodeBinaryAddressed(addressed)
+	b, _ := DecodeBinaryBroadcast(broadcast)
+	cases := []struct {
+		name    string
+		dac     uint16
+		fi      uint8
+		data    []byte
+		headers bool // The fields before the DAC are intact
+	}{
+		{"type 6", a.DAC, a.FID, a.BinaryData(), a.DestMMSI == 239876000 && !a.Retransmit},
+		{"type 8", b.DAC, b.FID, b.BinaryData(), b.MMSI == 366999508},
+	}
+	for _, c := range cases {
+		if c.dac != 513 || c.fi != 33 || bitsToInt(0, 2, c.data) != 5 || !c.headers {
+			fmt.Println("Got : ", c.dac, c.fi, bitsToInt(0, 2, c.data), c.headers)
+			fmt.Println("Want: ", 513, 33, 5, true)
+			t.Errorf("DAC/FI/data offsets of %s", c.name)
+		}
+	}
+}
+
+func TestDecodeBinaryData(t *testing.T) {
+	if _, err := DecodeBinaryData(366, 57, []byte("0000")); err == nil {
+		t.Errorf("DecodeBinaryData(dac uint16, fi uint8, data []byte): expected error for unknown DAC/FI")
+	}
+}
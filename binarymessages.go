@@ -0,0 +1,101 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+)
+
+// Here are the decoders for the application specific data of binary messages (types 6 and 8)
+// that are too small to deserve a file of their own. They take the binary data starting
+// at bit 0 (see BinaryBroadcast.BinaryData and BinaryAddressed.BinaryData).
+
+// PersonsOnBoard is the Number of Persons on Board message (DAC 1, FI 16 or FI 40).
+type PersonsOnBoard struct {
+	Persons uint16 // 0 means not available, 8191 means 8191 or more
+}
+
+// Available reports whether the message carries the number of persons (it isn't 0).
+func (m PersonsOnBoard) Available() bool {
+	return m.Persons != 0
+}
+
+// DecodePersonsOnBoard decodes the application specific data of a Number of Persons on Board
+// message. FI 16 is the addressed message (type 6) of IMO SN.1/Circ.289, FI 40 the broadcast
+// message (type 8) of ITU-R M.1371-1 it replaced. Both carry the same 13 bit field.
+func DecodePersonsOnBoard(data []byte) (PersonsOnBoard, error) {
+	var m PersonsOnBoard
+
+	if len(data)*6 < 13 {
+		return m, errors.New("Number of Persons on Board message is too short.")
+	}
+
+	m.Persons = uint16(bitsToInt(0, 12, data))
+
+	return m, nil
+}
+
+// VTSTargets is the VTS-Generated/Synthetic Targets message (DAC 1, FI 17). A VTS broadcasts
+// targets it tracks by radar or other means, so vessels see them as if they had AIS.
+type VTSTargets struct {
+	Targets []VTSTarget
+}
+
+// A VTSTarget is one of the targets of a VTSTargets message.
+type VTSTarget struct {
+	IDType   uint8   // Type of target identifier (enumeration at VTSTargetIDTypes)
+	ID       uint32  // MMSI or IMO number (IDType 0 or 1)
+	Callsign string  // Call sign or other identifier (IDType 2 or 3)
+	Lon      float64 // (sc 1/1000 min)
+	Lat      float64 // (sc 1/1000 min)
+	Course   uint16  // Degrees, 360 means not available
+	Second   uint8   // 60 means not available
+	Speed    uint8   // Knots, 255 means not available
+}
+
+// VTS target identifier types
+var VTSTargetIDTypes = [...]string{"MMSI", "IMO number", "Call sign", "Other"}
+
+// DecodeVTSTargets decodes the application specific data of a VTS-Generated/Synthetic Targets
+// message (DAC 1, FI 17). It is a broadcast message (type 8) carrying one to four 120 bit targets.
+func DecodeVTSTargets(data []byte) (VTSTargets, error) {
+	var m VTSTargets
+
+	if len(data)*6 < 120 {
+		return m, errors.New("VTS-Generated/Synthetic Targets message is too short.")
+	}
+
+	for first := 0; first+119 < len(data)*6; first += 120 {
+		var t VTSTarget
+		t.IDType = uint8(bitsToInt(first, first+1, data))
+		if t.IDType < 2 {
+			// The identifier is 42 bits but MMSI and IMO numbers fit in the last 30 of them
+			t.ID = bitsToInt(first+14, first+43, data)
+		} else {
+			t.Callsign = bitsToString(first+2, first+43, data)
+		}
+		t.Lat, _ = decodeLatitude(int64(bitsToSignedInt(first+48, first+71, data)), MinuteThousandths)
+		t.Lon, _ = decodeLongitude(int64(bitsToSignedInt(first+72, first+96, data)), MinuteThousandths)
+		t.Course = uint16(bitsToInt(first+97, first+105, data))
+		t.Second = uint8(bitsToInt(first+106, first+111, data))
+		t.Speed = uint8(bitsToInt(first+112, first+119, data))
+		m.Targets = append(m.Targets, t)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDecodePersonsOnBoard(t *testing.T) {
+	cases := []struct {
+		bits string
+		want PersonsOnBoard
+	}{
+		{bitField(0, 13) + bitField(0, 3), PersonsOnBoard{0}},
+		{bitField(1250, 13) + bitField(0, 3), PersonsOnBoard{1250}},
+		{bitField(8191, 13) + bitField(0, 3), PersonsOnBoard{8191}},
+	}
+	for _, c := range cases {
+		got, err := DecodePersonsOnBoard([]byte(armor(c.bits)))
+		if err != nil || got != c.want {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodePersonsOnBoard(data []byte)")
+		}
+		if got.Available() != (c.want.Persons != 0) {
+			t.Errorf("(PersonsOnBoard) Available()")
+		}
+	}
+
+	// The broadcast message (FI 40) carries the same field
+	got, err := DecodeBinaryData(1, 40, []byte(armor(bitField(37, 13)+bitField(0, 3))))
+	if err != nil || got != (PersonsOnBoard{37}) {
+		fmt.Println("Got : ", got, err)
+		fmt.Println("Want: ", PersonsOnBoard{37})
+		t.Errorf("DecodeBinaryData(dac uint16, fi uint8, data []byte) for DAC 1, FI 40")
+	}
+}
+
+func TestDecodeVTSTargets(t *testing.T) {
+	byMMSI := bitField(0, 2) + bitField(0, 12) + bitField(237012345, 30) + bitField(0, 4) +
+		bitField(37*60000+56000, 24) + bitField(23*60000+38000, 25) + bitField(275, 9) +
+		bitField(42, 6) + bitField(12, 8)
+	// Call sign "SV1234"
+	byCallsign := bitField(2, 2) + bitField(19, 6) + bitField(22, 6) + bitField(49, 6) +
+		bitField(50, 6) + bitField(51, 6) + bitField(52, 6) + bitField(0, 6) + bitField(0, 4) +
+		bitField(-(33*60000+30000), 24) + bitField(-(70*60000+45000), 25) + bitField(360, 9) +
+		bitField(60, 6) + bitField(255, 8)
+
+	want := VTSTargets{Targets: []VTSTarget{
+		{IDType: 0, ID: 237012345, Lon: 23.633333333333333, Lat: 37.93333333333333, Course: 275, Second: 42, Speed: 12},
+		{IDType: 2, Callsign: "SV1234", Lon: -70.75, Lat: -33.5, Course: 360, Second: 60, Speed: 255},
+	}}
+
+	got, err := DecodeBinaryData(1, 17, []byte(armor(byMMSI+byCallsign)))
+	if err != nil || !reflect.DeepEqual(got, want) {
+		fmt.Println("Got : ", got, err)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeVTSTargets(data []byte)")
+	}
+}
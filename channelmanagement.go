@@ -0,0 +1,173 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+)
+
+// A TxRxMode tells which channels the stations of an area may transmit on, as set by base stations
+// with channel management (type 22) and group assignment (type 23) messages.
+type TxRxMode uint8
+
+// Transmit/receive mode codes. Stations always receive on both channels, except where noted.
+const (
+	TxRxModeAB     TxRxMode = iota // Transmit on channels A and B
+	TxRxModeA                      // Transmit on channel A only
+	TxRxModeB                      // Transmit on channel B only
+	TxRxModeRxOnly                 // Do not transmit
+)
+
+// String returns a description of the transmit/receive mode
+func (m TxRxMode) String() string {
+	switch m {
+	case TxRxModeAB:
+		return "TxA/TxB, RxA/RxB"
+	case TxRxModeA:
+		return "TxA, RxA/RxB"
+	case TxRxModeB:
+		return "TxB, RxA/RxB"
+	case TxRxModeRxOnly:
+		return "RxA/RxB, no transmission"
+	}
+	return "not defined"
+}
+
+// A ChannelManagement is a decoded AIS Channel Management message (message type 22).
+// It is either addressed to two stations (Addressed true, DestMMSI1 and DestMMSI2 set) or
+// broadcast to the stations inside a rectangular region (NE and SW corners set).
+// Please have a look at http://catb.org/gpsd/AIVDM.html
+type ChannelManagement struct {
+	Repeat    uint8
+	MMSI      uint32
+	ChannelA  uint16 // Channel number, see ITU-R M.1084
+	ChannelB  uint16 // Channel number, see ITU-R M.1084
+	TxRx      TxRxMode
+	LowPower  bool // true for low (1W) power, false for high (12.5W)
+	NELon     float64
+	NELat     float64
+	SWLon     float64
+	SWLat     float64
+	DestMMSI1 uint32
+	DestMMSI2 uint32
+	Addressed bool
+	BandA     bool  // true if channel A uses 12.5kHz bandwidth, false for default
+	BandB     bool  // true if channel B uses 12.5kHz bandwidth, false for default
+	ZoneSize  uint8 // Transitional zone size, 0-7 means 1-8 nautical miles
+}
+
+// A GroupAssignment is a decoded AIS Group Assignment Command (message type 23). It sets the
+// transmit/receive mode, the reporting interval and a quiet time for the stations of a region
+// that match the station and ship type.
+type GroupAssignment struct {
+	Repeat      uint8
+	MMSI        uint32
+	NELon       float64
+	NELat       float64
+	SWLon       float64
+	SWLat       float64
+	StationType uint8 // Enumeration at StationTypes
+	ShipType    uint8 // 0 means all types, see ShipType at staticvoyagedata.go
+	TxRx        TxRxMode
+	Interval    uint8 // Reporting interval, enumeration at ReportingIntervals
+	QuietTime   uint8 // Minutes the stations should not transmit, 0 means none
+}
+
+// Station type codes of group assignment commands
+var StationTypes = [...]string{
+	"All types of mobiles", "Reserved for future use", "All types of Class B mobile stations",
+	"SAR airborne mobile station", "Aid to Navigation station", "Class B shipborne mobile station (CS only)",
+	"Regional use", "Regional use", "Regional use", "Regional use",
+	"Reserved for future use", "Reserved for future use", "Reserved for future use",
+	"Reserved for future use", "Reserved for future use", "Reserved for future use",
+}
+
+// Reporting interval codes of group assignment commands
+var ReportingIntervals = [...]string{
+	"As given by the autonomous mode", "10 minutes", "6 minutes", "3 minutes", "1 minute",
+	"30 seconds", "15 seconds", "10 seconds", "5 seconds", "Next shorter reporting interval",
+	"Next longer reporting interval", "2 seconds (Class B CS only)", "Reserved for future use",
+	"Reserved for future use", "Reserved for future use", "Reserved for future use",
+}
+
+// DecodeChannelManagement decodes [the payload of] an AIS Channel Management message (type 22)
+func DecodeChannelManagement(payload string) (ChannelManagement, error) {
+	data := []byte(payload)
+	var m ChannelManagement
+
+	mType := decodeAisChar(data[0])
+	if mType != 22 {
+		return m, errors.New("Message isn't Channel Management (type 22).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+
+	m.ChannelA = uint16(bitsToInt(40, 51, data))
+	m.ChannelB = uint16(bitsToInt(52, 63, data))
+	m.TxRx = TxRxMode(bitsToInt(64, 67, data))
+	m.LowPower = cbnBool(68, data)
+
+	m.Addressed = cbnBool(139, data)
+	if m.Addressed {
+		m.DestMMSI1 = bitsToInt(69, 98, data)
+		m.DestMMSI2 = bitsToInt(104, 133, data)
+	} else {
+		m.NELon, m.NELat = decodeRegionCorner(69, 87, data)
+		m.SWLon, m.SWLat = decodeRegionCorner(104, 122, data)
+	}
+
+	m.BandA = cbnBool(140, data)
+	m.BandB = cbnBool(141, data)
+	m.ZoneSize = uint8(bitsToInt(142, 144, data))
+
+	return m, nil
+}
+
+// DecodeGroupAssignment decodes [the payload of] an AIS Group Assignment Command (type 23)
+func DecodeGroupAssignment(payload string) (GroupAssignment, error) {
+	data := []byte(payload)
+	var m GroupAssignment
+
+	mType := decodeAisChar(data[0])
+	if mType != 23 {
+		return m, errors.New("Message isn't Group Assignment Command (type 23).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+
+	m.NELon, m.NELat = decodeRegionCorner(40, 58, data)
+	m.SWLon, m.SWLat = decodeRegionCorner(75, 93, data)
+
+	m.StationType = uint8(bitsToInt(110, 113, data))
+	m.ShipType = uint8(bitsToInt(114, 121, data))
+	m.TxRx = TxRxMode(bitsToInt(144, 145, data))
+	m.Interval = uint8(bitsToInt(146, 149, data))
+	m.QuietTime = uint8(bitsToInt(150, 153, data))
+
+	return m, nil
+}
+
+// decodeRegionCorner decodes a region corner of channel management and group assignment messages,
+// an 18 bit longitude and a 17 bit latitude in 1/10 min, and returns it in decimal degrees.
+func decodeRegionCorner(lonFirst, latFirst int, data []byte) (float64, float64) {
+	lon, _ := decodeLongitude(int64(bitsToSignedInt(lonFirst, lonFirst+17, data)), MinuteTenths)
+	lat, _ := decodeLatitude(int64(bitsToSignedInt(latFirst, latFirst+16, data)), MinuteTenths)
+	return lon, lat
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeChannelManagement(t *testing.T) {
+	addressed := bitField(22, 6) + bitField(0, 2) + bitField(2579999, 30) + bitField(0, 2) +
+		bitField(2087, 12) + bitField(2088, 12) + bitField(3, 4) + bitField(1, 1) +
+		bitField(257123000, 30) + bitField(0, 5) + bitField(257456000, 30) + bitField(0, 5) +
+		bitField(1, 1) + bitField(0, 1) + bitField(1, 1) + bitField(4, 3) + bitField(0, 23)
+
+	cases := []struct {
+		payload string
+		want    ChannelManagement
+	}{
+		{
+			"F030p:j2N2P5aJR0r;6f3rj10000",
+			ChannelManagement{
+				Repeat: 0, MMSI: 3160107, ChannelA: 2087, ChannelB: 2088, TxRx: TxRxModeAB, LowPower: false,
+				NELon: -128.5, NELat: 55, SWLon: -133.66666666666666, SWLat: 53.5, ZoneSize: 2,
+			},
+		},
+		{
+			armor(addressed),
+			ChannelManagement{
+				Repeat: 0, MMSI: 2579999, ChannelA: 2087, ChannelB: 2088, TxRx: TxRxModeRxOnly, LowPower: true,
+				DestMMSI1: 257123000, DestMMSI2: 257456000, Addressed: true, BandB: true, ZoneSize: 4,
+			},
+		},
+	}
+	for _, c := range cases {
+		got, _ := DecodeChannelManagement(c.payload)
+		if got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeChannelManagement(payload string)")
+		}
+	}
+}
+
+func TestDecodeGroupAssignment(t *testing.T) {
+	payload := "G02:Kn01R`sn@291nj600000900"
+	want := GroupAssignment{
+		Repeat: 0, MMSI: 2268120, NELon: 2.63, NELat: 51.07, SWLon: 1.8266666666666667, SWLat: 50.68,
+		StationType: 6, ShipType: 0, TxRx: TxRxModeAB, Interval: 9, QuietTime: 0,
+	}
+
+	got, _ := DecodeGroupAssignment(payload)
+	if got != want {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeGroupAssignment(payload string)")
+	}
+
+	// The real world sentence has no quiet time, set it to 15 minutes and the mode to TxB
+	bits := payloadBits(payload)
+	bits = bits[:144] + "10" + bits[146:150] + "1111" + bits[154:]
+	want.TxRx, want.QuietTime = TxRxModeB, 15
+	got, _ = DecodeGroupAssignment(armor(bits))
+	if got != want {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeGroupAssignment(payload string)")
+	}
+}
+
+func TestTxRxMode(t *testing.T) {
+	cases := []struct {
+		mode TxRxMode
+		want string
+	}{
+		{TxRxModeAB, "TxA/TxB, RxA/RxB"},
+		{TxRxModeB, "TxB, RxA/RxB"},
+		{TxRxModeRxOnly, "RxA/RxB, no transmission"},
+		{TxRxMode(7), "not defined"},
+	}
+	for _, c := range cases {
+		if got := c.mode.String(); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("(TxRxMode) String()")
+		}
+	}
+}
@@ -23,10 +23,10 @@ package aislib
 // cbnCoordinates takes the start of the coordinates block and returns coordinates in
 // decimal degrees
 func cbnCoordinates(first int, data []byte) (float64, float64) {
-	lon := float64((int32(bitsToInt(first, first+27, data)) << 4)) / 16
-	lat := float64((int32(bitsToInt(first+28, first+54, data)) << 5)) / 32
+	lon, _ := decodeLongitude(int64(bitsToSignedInt(first, first+27, data)), MinuteTenThousandths)
+	lat, _ := decodeLatitude(int64(bitsToSignedInt(first+28, first+54, data)), MinuteTenThousandths)
 
-	return CoordinatesMin2Deg(lon, lat)
+	return lon, lat
 }
 
 // cbnSpeed takes the start of the speed block and returns speed in knots or 1023.
@@ -0,0 +1,116 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+// A CommState is a decoded communication state, the radio status field (19 bits) that ends most
+// position reports. It is either SOTDMA or ITDMA, depending on the message type or, for some
+// types, on a communication state selector flag. Only the fields of its format are set.
+// For SOTDMA only one sub message field is set, depending on SlotTimeout.
+// Please have a look at ITU-R M.1371-5, Annex 2, 3.3.7.
+type CommState struct {
+	ITDMA     bool
+	SyncState uint8 // 0 UTC direct, 1 UTC indirect, 2 synced to a base station, 3 synced to another station
+	// SOTDMA
+	SlotTimeout      uint8  // Frames left until a new slot is selected
+	ReceivedStations uint16 // Slot timeout 3, 5 and 7
+	SlotNumber       uint16 // Slot timeout 2, 4 and 6
+	UTCHour          uint8  // Slot timeout 1
+	UTCMinute        uint8  // Slot timeout 1
+	SlotOffset       uint16 // Slot timeout 0
+	// ITDMA
+	SlotIncrement uint16
+	NumberOfSlots uint8
+	KeepFlag      bool
+}
+
+// decodeCommState decodes the 19 bit communication state starting at bit first, in SOTDMA
+// or ITDMA format.
+func decodeCommState(first int, itdma bool, data []byte) CommState {
+	var c CommState
+
+	c.ITDMA = itdma
+	c.SyncState = uint8(bitsToInt(first, first+1, data))
+	if itdma {
+		c.SlotIncrement = uint16(bitsToInt(first+2, first+14, data))
+		c.NumberOfSlots = uint8(bitsToInt(first+15, first+17, data))
+		c.KeepFlag = cbnBool(first+18, data)
+		return c
+	}
+
+	c.SlotTimeout = uint8(bitsToInt(first+2, first+4, data))
+	switch c.SlotTimeout {
+	case 3, 5, 7:
+		c.ReceivedStations = uint16(bitsToInt(first+5, first+18, data))
+	case 2, 4, 6:
+		c.SlotNumber = uint16(bitsToInt(first+5, first+18, data))
+	case 1:
+		c.UTCHour = uint8(bitsToInt(first+5, first+9, data))
+		c.UTCMinute = uint8(bitsToInt(first+10, first+16, data))
+	case 0:
+		c.SlotOffset = uint16(bitsToInt(first+5, first+18, data))
+	}
+
+	return c
+}
+
+// ReservedSlots returns the slot numbers (0 to FrameSlots-1) the station has reserved according
+// to the communication state, given the slot the message was received in. It takes the received
+// slot rather than the current frame: the sub messages tell slots relative to the slot of the
+// transmission, and which frame is current doesn't change the slot numbers. It assumes a frame of
+// FrameSlots (2250) slots per minute and channel, reserved slots past the end of the frame wrap
+// into the next one. A slot outside 0 to FrameSlots-1 isn't a slot of the frame, so no slots are
+// returned for it.
+//
+// For SOTDMA it is the slot that stays reserved for the remaining SlotTimeout frames: the received
+// slot (received stations sub message), the reported SlotNumber, or the slot the station moves to in
+// the next frame (slot offset sub message, none if the offset is 0). The UTC hour and minute sub
+// message doesn't tell a slot, so no slots are returned for it. For ITDMA they are the NumberOfSlots
+// consecutive slots SlotIncrement slots after the received one, none if the increment is 0.
+func (c CommState) ReservedSlots(slot int) []int {
+	if slot < 0 || slot >= FrameSlots {
+		return nil
+	}
+	if c.ITDMA {
+		if c.SlotIncrement == 0 {
+			return nil
+		}
+		// Values 0-4 mean 1-5 slots, 5-7 mean 1-3 slots with the increment extended by 8192 slots
+		count, increment := int(c.NumberOfSlots)+1, int(c.SlotIncrement)
+		if c.NumberOfSlots > 4 {
+			count, increment = int(c.NumberOfSlots)-4, increment+8192
+		}
+		slots := make([]int, count)
+		for i := range slots {
+			slots[i] = (slot + increment + i) % FrameSlots
+		}
+		return slots
+	}
+
+	switch c.SlotTimeout {
+	case 3, 5, 7:
+		return []int{slot % FrameSlots}
+	case 2, 4, 6:
+		return []int{int(c.SlotNumber) % FrameSlots}
+	case 0:
+		if c.SlotOffset == 0 {
+			return nil
+		}
+		return []int{(slot + int(c.SlotOffset)) % FrameSlots}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCommStateReservedSlots(t *testing.T) {
+	cases := []struct {
+		state CommState
+		slot  int
+		want  []int
+	}{
+		{CommState{SlotTimeout: 3, ReceivedStations: 12}, 1200, []int{1200}},
+		{CommState{SlotTimeout: 4, SlotNumber: 1201}, 1200, []int{1201}},
+		{CommState{SlotTimeout: 0, SlotOffset: 2200}, 1200, []int{1150}}, // Next frame
+		{CommState{SlotTimeout: 0, SlotOffset: 0}, 1200, nil},
+		{CommState{SlotTimeout: 1, UTCHour: 12, UTCMinute: 30}, 1200, nil},
+		{CommState{ITDMA: true, SlotIncrement: 100, NumberOfSlots: 2}, 1200, []int{1300, 1301, 1302}},
+		{CommState{ITDMA: true, SlotIncrement: 100, NumberOfSlots: 5}, 2149, []int{(2149 + 8292) % FrameSlots}},
+		{CommState{ITDMA: true, SlotIncrement: 0, NumberOfSlots: 2}, 1200, nil},
+		// Not a slot of the frame
+		{CommState{SlotTimeout: 3}, -1, nil},
+		{CommState{SlotTimeout: 0, SlotOffset: 10}, FrameSlots, nil},
+		{CommState{ITDMA: true, SlotIncrement: 100, NumberOfSlots: 2}, -50, nil},
+	}
+	for _, c := range cases {
+		got := c.state.ReservedSlots(c.slot)
+		if !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("(CommState) ReservedSlots(slot int) for %+v", c.state)
+		}
+	}
+}
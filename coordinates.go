@@ -22,6 +22,65 @@ import (
 	"math"
 )
 
+// Positioned is implemented by the decoded messages that carry a position (types 1, 2, 3, 4, 9, 11,
+// 18, 19, 21 and 27), so that generic code (geofencing, distance, export) can handle them uniformly.
+// Position returns the coordinates in decimal degrees and false if they aren't available.
+type Positioned interface {
+	Position() (lat, lon float64, ok bool)
+}
+
+// positionFields holds where the coordinates are in the messages that carry a position:
+// the first bit of the longitude, the widths of the longitude and latitude (which follows it)
+// and their resolution.
+var positionFields = map[uint8]struct {
+	lon, lonBits, latBits int
+	res                   Resolution
+}{
+	1: {61, 28, 27, MinuteTenThousandths}, 2: {61, 28, 27, MinuteTenThousandths},
+	3: {61, 28, 27, MinuteTenThousandths}, 4: {79, 28, 27, MinuteTenThousandths},
+	9: {61, 28, 27, MinuteTenThousandths}, 11: {79, 28, 27, MinuteTenThousandths},
+	18: {57, 28, 27, MinuteTenThousandths}, 19: {57, 28, 27, MinuteTenThousandths},
+	21: {164, 28, 27, MinuteTenThousandths}, 27: {44, 18, 17, MinuteTenths},
+}
+
+// Position returns the coordinates of a message that carries a position (types 1, 2, 3, 4, 9, 11,
+// 18, 19, 21 and 27) in decimal degrees, reading only their bits. It is the cheap counterpart of
+// Positioned for filters (e.g geofencing) that drop most messages: it doesn't decode, nor
+// allocate, the full report. It returns false for other types, payloads too short to carry the
+// position and not available coordinates.
+func Position(m *Message) (lat, lon float64, ok bool) {
+	if m == nil {
+		return 0, 0, false
+	}
+	f, ok := positionFields[m.Type]
+	if !ok || len(m.Payload)*6 < f.lon+f.lonBits+f.latBits {
+		return 0, 0, false
+	}
+
+	lon, lonOK := decodeLongitude(payloadSignedInt(m.Payload, f.lon, f.lonBits), f.res)
+	lat, latOK := decodeLatitude(payloadSignedInt(m.Payload, f.lon+f.lonBits, f.latBits), f.res)
+	return lat, lon, lonOK && latOK
+}
+
+// payloadSignedInt reads the signed field of width bits starting at bit first of an armored
+// payload, straight from the string so that it doesn't allocate. The caller checks the length.
+func payloadSignedInt(payload string, first, width int) int64 {
+	last := first + width - 1
+	var v int64
+	for c := first / 6; c <= last/6; c++ { // Whole characters, at most 6 for a 28 bit field
+		v = v<<6 | int64(decodeAisChar(payload[c]))
+	}
+	v >>= uint(5 - last%6) // Drop the bits after the field
+	shift := uint(64 - width)
+	return v << shift >> shift // and the bits before it, extending the sign
+}
+
+// positionAvailable reports whether the coordinates are valid, not the not-available
+// sentinels (longitude 181 and latitude 91) or out of range.
+func positionAvailable(lon, lat float64) bool {
+	return lon >= -180 && lon <= 180 && lat >= -90 && lat <= 90
+}
+
 // CoordinatesMin2Deg translates coordinates (lon, lat) in decimal minutes (×10^4) to decimal degrees.
 // AIS data use decimal minutes but decimal degrees (DD) is a more universal format and easier to
 // handle. Almost every third party asks for this format.
@@ -49,6 +108,31 @@ func CoordinatesMin2Deg(minLon, minLat float64) (float64, float64) {
 	return lonSign * lon, latSign * lat
 }
 
+// A Resolution is the scale of the coordinates of a message field, in fractions of a minute.
+type Resolution int64
+
+// Coordinate resolutions used by AIS messages
+const (
+	MinuteTenThousandths Resolution = 10000 // Position reports, e.g types 1, 2, 3, 4, 9, 11, 18 and 19
+	MinuteThousandths    Resolution = 1000  // Binary messages, e.g area notice, met/hydro
+	MinuteTenths         Resolution = 10    // Types 17, 22, 23 and 27
+)
+
+// decodeLongitude converts a raw longitude field of resolution res to decimal degrees. It returns
+// false for the not available value (181°) and out of range values. Since the not available
+// value is in degrees, it is the same for all resolutions.
+func decodeLongitude(raw int64, res Resolution) (float64, bool) {
+	lon, _ := CoordinatesMin2Deg(float64(raw*int64(MinuteTenThousandths/res)), 0)
+	return lon, lon >= -180 && lon <= 180
+}
+
+// decodeLatitude converts a raw latitude field of resolution res to decimal degrees. It returns
+// false for the not available value (91°) and out of range values.
+func decodeLatitude(raw int64, res Resolution) (float64, bool) {
+	_, lat := CoordinatesMin2Deg(0, float64(raw*int64(MinuteTenThousandths/res)))
+	return lat, lat >= -90 && lat <= 90
+}
+
 // CoordinatesDeg2Human takes coordinates (lon, lat) in decimal degrees (DD),
 // formats them as degrees minutes and returns them as string.
 func CoordinatesDeg2Human(degLon, degLat float64) string {
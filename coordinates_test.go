@@ -19,6 +19,8 @@ package aislib
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -44,3 +46,147 @@ func ExampleCoordinatesDeg2Human() {
 	fmt.Println(CoordinatesDeg2Human(-3.56725, 53.84251666666667))
 	// Output:   3°34.0350'W  53°50.5510N
 }
+
+func TestPositioned(t *testing.T) {
+	classA, _ := DecodeClassAPositionReport("38u<a<?PAA2>P:WfuAO9PW<P0PuQ")
+	baseStation, _ := DecodeBaseStationReport("402R3KiutR0Qk156V4QQTOA00<0;")
+	longRange, _ := DecodeLongRangePositionReport("KC5E2b@U19PFdLbL")
+	notAvailable := ClassBPositionReport{PositionReport: PositionReport{Lon: 181, Lat: 91}}
+
+	cases := []struct {
+		message  Positioned
+		lat, lon float64
+		ok       bool
+	}{
+		{classA, classA.Lat, classA.Lon, true},
+		{baseStation, 58.588368333333335, 15.09579, true},
+		{longRange, 4.84, 137.02333333333334, true},
+		{notAvailable, 91, 181, false},
+	}
+	for _, c := range cases {
+		lat, lon, ok := c.message.Position()
+		if lat != c.lat || lon != c.lon || ok != c.ok {
+			fmt.Println("Got : ", lat, lon, ok)
+			fmt.Println("Want: ", c.lat, c.lon, c.ok)
+			t.Errorf("Positioned.Position()")
+		}
+	}
+}
+
+func TestPosition(t *testing.T) {
+	payloads := []*Message{
+		{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0},
+		{4, "402R3KiutR0Qk156V4QQTOA00<0;", 0},
+		{11, ";4R33:1uUK2F`q?mOt@@GoQ00000", 0},
+		{18, "B3ujWF0000DdVU8O:1H03wi5oP06", 0},
+		{27, "KC5E2b@U19PFdLbL", 0},
+	}
+	for _, m := range payloads {
+		decoded, err := messageDecoders[m.Type](m.Payload)
+		if err != nil {
+			t.Fatalf("decoding type %d: %s", m.Type, err)
+		}
+		wantLat, wantLon, wantOK := decoded.(Positioned).Position()
+		lat, lon, ok := Position(m)
+		if lat != wantLat || lon != wantLon || ok != wantOK {
+			fmt.Println("Got : ", lat, lon, ok)
+			fmt.Println("Want: ", wantLat, wantLon, wantOK)
+			t.Errorf("Position(m *Message) for type %d", m.Type)
+		}
+	}
+
+	notAvailable := armor(bitField(1, 6) + bitField(0, 55) + bitField(181*600000, 28) + bitField(91*600000, 27))
+	for _, m := range []*Message{
+		{1, notAvailable, 0},
+		{5, "533iFNT00003W;3G;384iT<T400000000000001?88?73v0ik0RC1H11H30H51CU0E2CkP0", 2},
+		{1, "38u<a<?PAA2>P", 0},
+		nil,
+	} {
+		if _, _, ok := Position(m); ok {
+			t.Errorf("Position(m *Message) should not be available for %v", m)
+		}
+	}
+}
+
+// Coordinates are two's complement, read naively southern latitudes and western longitudes end up
+// on the other side of the planet. Check every message layout, including the extremes.
+func TestSouthernWesternCoordinates(t *testing.T) {
+	cases := []struct{ lon, lat float64 }{
+		{-70.5, -33.25},
+		{-180, -90},
+		{-1.0 / 600, -1.0 / 600}, // The smallest negative value of type 27, all bits set
+		{70.5, -33.25},
+		{-70.5, 33.25},
+	}
+	for mType, f := range positionFields {
+		min, _, _ := ExpectedBits(mType)
+		for _, c := range cases {
+			lon := int64(math.Round(c.lon * 60 * float64(f.res)))
+			lat := int64(math.Round(c.lat * 60 * float64(f.res)))
+			bits := bitField(int64(mType), 6) + strings.Repeat("0", f.lon-6) +
+				bitField(lon, f.lonBits) + bitField(lat, f.latBits)
+			bits += strings.Repeat("0", min-len(bits))
+			m := &Message{mType, armor(bits), 0}
+
+			decoded, err := messageDecoders[mType](m.Payload)
+			if err != nil {
+				t.Fatalf("decoding type %d: %s", mType, err)
+			}
+			gotLat, gotLon, _ := decoded.(Positioned).Position()
+			if math.Abs(gotLon-c.lon) > 1e-9 || math.Abs(gotLat-c.lat) > 1e-9 {
+				fmt.Println("Got : ", gotLon, gotLat)
+				fmt.Println("Want: ", c.lon, c.lat)
+				t.Errorf("Positioned.Position() for type %d", mType)
+			}
+			gotLat, gotLon, ok := Position(m)
+			if math.Abs(gotLon-c.lon) > 1e-9 || math.Abs(gotLat-c.lat) > 1e-9 || !ok {
+				fmt.Println("Got : ", gotLon, gotLat, ok)
+				fmt.Println("Want: ", c.lon, c.lat, true)
+				t.Errorf("Position(m *Message) for type %d", mType)
+			}
+		}
+	}
+}
+
+// Position should report 0 allocs/op and be much faster than a full decode.
+func BenchmarkPosition(b *testing.B) {
+	m := &Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Position(m)
+	}
+}
+
+func BenchmarkPositionFullDecode(b *testing.B) {
+	m := &Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		r, _ := DecodeClassAPositionReport(m.Payload)
+		r.Position()
+	}
+}
+
+func TestDecodeLongitudeLatitude(t *testing.T) {
+	cases := []struct {
+		lon, lat         int64
+		res              Resolution
+		wantLon, wantLat float64
+		ok               bool
+	}{
+		{-3767310, 32961002, MinuteTenThousandths, -6.27885, 54.93500333333333, true},
+		{108600000, 54600000, MinuteTenThousandths, 181, 91, false},
+		{-376731, 3296100, MinuteThousandths, -6.27885, 54.935, true},
+		{10860000, 5460000, MinuteThousandths, 181, 91, false},
+		{82214, 2904, MinuteTenths, 137.02333333333334, 4.84, true},
+		{108600, 54600, MinuteTenths, 181, 91, false},
+	}
+	for _, c := range cases {
+		lon, lonOk := decodeLongitude(c.lon, c.res)
+		lat, latOk := decodeLatitude(c.lat, c.res)
+		if math.Abs(lon-c.wantLon) > 1e-9 || math.Abs(lat-c.wantLat) > 1e-9 || lonOk != c.ok || latOk != c.ok {
+			fmt.Println("Got : ", lon, lat, lonOk, latOk)
+			fmt.Println("Want: ", c.wantLon, c.wantLat, c.ok)
+			t.Errorf("decodeLongitude(raw int64, res Resolution), decodeLatitude(raw int64, res Resolution)")
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+	"time"
+)
+
+// DangerousCargo is a decoded Dangerous Cargo Indication message (DAC 1, FI 12) of IMO
+// SN/Circ.236. Ships send it to announce the main dangerous goods they carry and their voyage.
+// Stations may omit the trailing fields. Fields the message is too short to carry are zero,
+// which is their not available value.
+type DangerousCargo struct {
+	LastPort       string    // UN/LOCODE of the last port of call, empty if not available
+	Departure      time.Time // UTC departure from the last port. Does not include year, like the ETA of type 5
+	NextPort       string    // UN/LOCODE of the next port of call, empty if not available
+	ETA            time.Time // UTC ETA at the next port. Does not include year, like the ETA of type 5
+	DangerousGoods string    // Main dangerous good
+	IMDCategory    string    // IMDG code class or division, e.g "3.1"
+	UNNumber       uint16    // UN number of the dangerous good, 0 means not available
+	Amount         uint16    // Quantity of the dangerous good, in Unit
+	Unit           uint8     // Unit of Amount (enumeration at DangerousCargoUnits)
+}
+
+// Dangerous cargo amount units
+var DangerousCargoUnits = [...]string{"not available", "kg", "tonnes", "1000 tonnes"}
+
+// DecodeDangerousCargo decodes the application specific data of a Dangerous Cargo Indication
+// message (DAC 1, FI 12). The full message is 272 bits, it should carry at least the ports of
+// call and their times (100 bits). Ports that aren't valid UN/LOCODEs are left empty.
+func DecodeDangerousCargo(data []byte) (DangerousCargo, error) {
+	var m DangerousCargo
+
+	if len(data)*6 < 100 {
+		return m, errors.New("Dangerous Cargo Indication message is too short.")
+	}
+
+	m.LastPort, _ = parseUNLOCODE(bitsToString(0, 29, data))
+	m.Departure = decodeInlandTime(30, data)
+	m.NextPort, _ = parseUNLOCODE(bitsToString(50, 79, data))
+	m.ETA = decodeInlandTime(80, data)
+
+	m.DangerousGoods = bitsToString(100, 219, data)
+	m.IMDCategory = bitsToString(220, 243, data)
+	m.UNNumber = uint16(bitsToInt(244, 256, data))
+	m.Amount = uint16(bitsToInt(257, 266, data))
+	m.Unit = uint8(bitsToInt(267, 268, data))
+
+	return m, nil
+}
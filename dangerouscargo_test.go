@@ -0,0 +1,62 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeDangerousCargo(t *testing.T) {
+	voyage := sixBitText("NLRTM", 5) + bitField(3, 4) + bitField(14, 5) + bitField(9, 5) + bitField(30, 6) +
+		sixBitText("GRPIR", 5) + bitField(3, 4) + bitField(19, 5) + bitField(22, 5) + bitField(0, 6)
+	cargo := sixBitText("GASOLINE", 20) + sixBitText("3", 4) + bitField(1203, 13) + bitField(850, 10) +
+		bitField(2, 2) + bitField(0, 3)
+
+	short := DangerousCargo{
+		LastPort: "NLRTM", Departure: time.Date(0, 3, 14, 9, 30, 0, 0, time.UTC),
+		NextPort: "GRPIR", ETA: time.Date(0, 3, 19, 22, 0, 0, 0, time.UTC),
+	}
+	full := short
+	full.DangerousGoods, full.IMDCategory, full.UNNumber, full.Amount, full.Unit = "GASOLINE", "3", 1203, 850, 2
+	noLOCODE := short
+	noLOCODE.LastPort = ""
+
+	cases := []struct {
+		bits string
+		want DangerousCargo
+	}{
+		{voyage + cargo, full},
+		{voyage, short}, // Trailing fields omitted
+		{sixBitText("HOME", 5) + voyage[30:], noLOCODE},
+	}
+	for _, c := range cases {
+		got, err := DecodeBinaryData(1, 12, []byte(armor(c.bits)))
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeBinaryData(dac uint16, fi uint8, data []byte) for %d bits", len(c.bits))
+		}
+	}
+
+	if _, err := DecodeDangerousCargo([]byte(armor(voyage[:90]))); err == nil {
+		t.Errorf("DecodeDangerousCargo(data []byte): expected error for short message")
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+)
+
+// A DataLinkManagement is a decoded AIS Data Link Management message (message type 20). Base
+// stations use it to reserve slots of the TDMA frame for their own transmissions.
+// Please have a look at http://catb.org/gpsd/AIVDM.html and ITU-R M.1371-5, 3.20.
+type DataLinkManagement struct {
+	Repeat       uint8
+	MMSI         uint32
+	Reservations []DataLinkReservation // One to four
+}
+
+// A DataLinkReservation is one of the reservation blocks of a DataLinkManagement message.
+// Offset is relative to the slot the message was received in. The reservation is repeated every
+// Increment slots within the frame, an increment of 0 means once per frame. The reservation lasts
+// for Timeout minutes (frames). Offset, Number and Timeout of 0 mean the block is not available.
+type DataLinkReservation struct {
+	Offset    uint16 // Reserved offset number (slots)
+	Number    uint8  // Number of consecutive slots reserved, 1-15
+	Timeout   uint8  // Minutes, 1-7
+	Increment uint16 // Slots between repetitions of the block, 0 means one block per frame
+}
+
+// FrameSlots is the number of TDMA slots in a frame (one minute) of an AIS channel.
+const FrameSlots = 2250
+
+// DecodeDataLinkManagement decodes [the payload of] an AIS Data Link Management message (type 20).
+// The number of reservations is derived from the payload length, unused trailing blocks are
+// dropped.
+func DecodeDataLinkManagement(payload string) (DataLinkManagement, error) {
+	data := []byte(payload)
+	var m DataLinkManagement
+
+	mType := decodeAisChar(data[0])
+	if mType != 20 {
+		return m, errors.New("Message isn't Data Link Management (type 20).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+
+	for first := 40; first+29 < len(data)*6 && len(m.Reservations) < 4; first += 30 {
+		r := DataLinkReservation{
+			Offset:    uint16(bitsToInt(first, first+11, data)),
+			Number:    uint8(bitsToInt(first+12, first+15, data)),
+			Timeout:   uint8(bitsToInt(first+16, first+18, data)),
+			Increment: uint16(bitsToInt(first+19, first+29, data)),
+		}
+		if r.Offset == 0 && r.Number == 0 && r.Timeout == 0 && r.Increment == 0 {
+			break
+		}
+		m.Reservations = append(m.Reservations, r)
+	}
+
+	return m, nil
+}
+
+// ReservedSlots returns the slot numbers (0 to FrameSlots-1) the reservation holds in the frame, given
+// the slot the message was received in. The blocks start at slot+Offset and repeat every Increment
+// slots until the frame is covered. It returns nil if the reservation isn't available or if slot
+// isn't a slot of the frame (outside 0 to FrameSlots-1).
+func (r DataLinkReservation) ReservedSlots(slot int) []int {
+	if slot < 0 || slot >= FrameSlots || r.Offset == 0 || r.Number == 0 || r.Timeout == 0 {
+		return nil
+	}
+
+	blocks := 1
+	if r.Increment > 0 {
+		blocks = FrameSlots / int(r.Increment)
+	}
+
+	slots := make([]int, 0, blocks*int(r.Number))
+	for b := 0; b < blocks; b++ {
+		start := slot + int(r.Offset) + b*int(r.Increment)
+		for i := 0; i < int(r.Number); i++ {
+			slots = append(slots, (start+i)%FrameSlots)
+		}
+	}
+	return slots
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeDataLinkManagement(t *testing.T) {
+	cases := []struct {
+		payload string
+		want    DataLinkManagement
+	}{
+		{
+			"Dh3OvjB8IN>4",
+			DataLinkManagement{Repeat: 3, MMSI: 3669705, Reservations: []DataLinkReservation{
+				{Offset: 2182, Number: 5, Timeout: 7, Increment: 225},
+			}},
+		},
+		{
+			"D028rqP<QNfp000000000000000",
+			DataLinkManagement{Repeat: 0, MMSI: 2243302, Reservations: []DataLinkReservation{
+				{Offset: 200, Number: 5, Timeout: 7, Increment: 750},
+			}},
+		},
+	}
+	for _, c := range cases {
+		got, _ := DecodeDataLinkManagement(c.payload)
+		if !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeDataLinkManagement(payload string)")
+		}
+	}
+}
+
+func TestDataLinkReservationReservedSlots(t *testing.T) {
+	cases := []struct {
+		reservation DataLinkReservation
+		slot        int
+		want        []int
+	}{
+		{
+			DataLinkReservation{Offset: 200, Number: 5, Timeout: 7, Increment: 750}, 100,
+			[]int{300, 301, 302, 303, 304, 1050, 1051, 1052, 1053, 1054, 1800, 1801, 1802, 1803, 1804},
+		},
+		// The blocks wrap around the end of the frame
+		{
+			DataLinkReservation{Offset: 2182, Number: 2, Timeout: 7, Increment: 1125}, 68,
+			[]int{0, 1, 1125, 1126},
+		},
+		{DataLinkReservation{Offset: 10, Number: 3, Timeout: 1, Increment: 0}, 2248, []int{8, 9, 10}},
+		{DataLinkReservation{Offset: 10, Number: 3, Timeout: 0, Increment: 0}, 0, nil},
+		// Not a slot of the frame
+		{DataLinkReservation{Offset: 10, Number: 3, Timeout: 1, Increment: 0}, -20, nil},
+		{DataLinkReservation{Offset: 10, Number: 3, Timeout: 1, Increment: 0}, FrameSlots, nil},
+	}
+	for _, c := range cases {
+		got := c.reservation.ReservedSlots(c.slot)
+		if !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("(DataLinkReservation) ReservedSlots(slot int)")
+		}
+	}
+}
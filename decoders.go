@@ -0,0 +1,188 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+)
+
+// messageDecoders are the decoding functions of the message types we understand, indexed by
+// message type (like binaryDecoders for the application specific data of binary messages).
+var messageDecoders = map[uint8]func(payload string) (interface{}, error){
+	1:  func(payload string) (interface{}, error) { return DecodeClassAPositionReport(payload) },
+	2:  func(payload string) (interface{}, error) { return DecodeClassAPositionReport(payload) },
+	3:  func(payload string) (interface{}, error) { return DecodeClassAPositionReport(payload) },
+	4:  func(payload string) (interface{}, error) { return DecodeBaseStationReport(payload) },
+	5:  func(payload string) (interface{}, error) { return DecodeStaticVoyageData(payload) },
+	6:  func(payload string) (interface{}, error) { return DecodeBinaryAddressed(payload) },
+	7:  func(payload string) (interface{}, error) { return DecodeBinaryAcknowledge(payload) },
+	8:  func(payload string) (interface{}, error) { return DecodeBinaryBroadcast(payload) },
+	9:  func(payload string) (interface{}, error) { return DecodeSARAircraftPositionReport(payload) },
+	11: func(payload string) (interface{}, error) { return DecodeUTCDateResponse(payload) },
+	12: func(payload string) (interface{}, error) { return DecodeAddressedSafetyMessage(payload) },
+	13: func(payload string) (interface{}, error) { return DecodeSafetyAcknowledge(payload) },
+	15: func(payload string) (interface{}, error) { return DecodeInterrogation(payload) },
+	16: func(payload string) (interface{}, error) { return DecodeAssignmentModeCommand(payload) },
+	18: func(payload string) (interface{}, error) { return DecodeClassBPositionReport(payload) },
+	19: func(payload string) (interface{}, error) { return DecodeExtendedClassBPositionReport(payload) },
+	20: func(payload string) (interface{}, error) { return DecodeDataLinkManagement(payload) },
+	21: func(payload string) (interface{}, error) { return DecodeAidToNavigationReport(payload) },
+	22: func(payload string) (interface{}, error) { return DecodeChannelManagement(payload) },
+	23: func(payload string) (interface{}, error) { return DecodeGroupAssignment(payload) },
+	24: func(payload string) (interface{}, error) { return DecodeStaticDataReport(payload) },
+	27: func(payload string) (interface{}, error) { return DecodeLongRangePositionReport(payload) },
+}
+
+// SupportedTypes returns the message types aislib can decode, in ascending order.
+// It is derived from the decoders we have, so it is always up to date.
+func SupportedTypes() []uint8 {
+	types := make([]uint8, 0, len(messageDecoders))
+	for t := uint8(0); t < 64; t++ { // The message type field is six bits
+		if _, ok := messageDecoders[t]; ok {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// DecodeRaw decodes a bare payload, e.g an example copied from the specification, without an NMEA
+// sentence around it. The type is inferred from the payload and padding is its number of fill
+// bits. The result is one of the decoded message types, as with DecodeAll. Payloads too short
+// for their type (see ExpectedBits) fail with an error wrapping ErrMalformed.
+func DecodeRaw(payload string, padding uint8) (interface{}, error) {
+	if len(payload) == 0 {
+		return nil, ErrMalformed
+	}
+	if _, err := ArmorDecode(payload, int(padding)); err != nil {
+		return nil, err
+	}
+
+	mType := MessageType(payload)
+	if err := checkLength(mType, len(payload)*6-int(padding)); err != nil {
+		return nil, err
+	}
+	decoder, ok := messageDecoders[mType]
+	if !ok {
+		return nil, fmt.Errorf("no decoder for message type %d", mType)
+	}
+	return decoder(payload)
+}
+
+// A DecodedSet holds a batch of decoded messages, grouped by category. Positions holds every
+// message that carries a position (see Positioned), Static the static data (types 5 and 24),
+// Safety the safety related messages, Binary the binary messages (types 6 and 8, application
+// data not decoded) and Other the rest. Within each group messages keep their order.
+// Warnings lists the messages whose length doesn't conform to their type, they are decoded anyway.
+type DecodedSet struct {
+	Positions []Positioned
+	Static    []interface{}
+	Safety    []interface{}
+	Binary    []interface{}
+	Other     []interface{}
+	Errors    []DecodeError
+	Warnings  []LengthWarning
+}
+
+// A DecodeError is the error of a message of a batch that failed to decode, with its index
+// in the batch.
+type DecodeError struct {
+	Index int
+	Err   error
+}
+
+func (e DecodeError) Error() string {
+	return fmt.Sprintf("message %d: %s", e.Index, e.Err)
+}
+
+// A LengthWarning reports a message of a batch that is shorter or longer than ExpectedBits for its
+// type, with its index in the batch. Non-conforming transponders are common, so it is a measure
+// of feed quality rather than an error.
+type LengthWarning struct {
+	Index    int
+	Bits     int // Actual length (see Message.BitLength)
+	Min, Max int // Expected length
+}
+
+func (w LengthWarning) String() string {
+	return fmt.Sprintf("message %d: length %d bits, expected %d-%d", w.Index, w.Bits, w.Min, w.Max)
+}
+
+// checkLength returns an error wrapping ErrMalformed if a message of type mType and bits length
+// is too short to decode, i.e shorter than the ExpectedBits minimum. Type 5 messages are accepted
+// down to 420 bits, as some transponders send them short.
+func checkLength(mType uint8, bits int) error {
+	min, _, ok := ExpectedBits(mType)
+	if mType == 5 {
+		min = 420
+	}
+	if ok && bits < min {
+		return fmt.Errorf("%w: type %d message of %d bits, expected at least %d", ErrMalformed, mType, bits, min)
+	}
+	return nil
+}
+
+// DecodeAll decodes a batch of messages (e.g as returned from Router) and groups them by category.
+// Messages that fail to decode, are too short to decode (see ExpectedBits), or that we don't have
+// a decoder for, are reported in Errors and don't stop the batch. Messages with a non-conforming
+// length are reported in Warnings.
+func DecodeAll(messages []*Message) DecodedSet {
+	var set DecodedSet
+
+	for i, m := range messages {
+		if m == nil || len(m.Payload) == 0 {
+			set.Errors = append(set.Errors, DecodeError{i, ErrMalformed})
+			continue
+		}
+		if min, max, ok := ExpectedBits(m.Type); ok {
+			if bits := m.BitLength(); bits < min || bits > max {
+				set.Warnings = append(set.Warnings, LengthWarning{i, bits, min, max})
+			}
+		}
+		if err := checkLength(m.Type, m.BitLength()); err != nil {
+			set.Errors = append(set.Errors, DecodeError{i, err})
+			continue
+		}
+		decoder, ok := messageDecoders[m.Type]
+		if !ok {
+			set.Errors = append(set.Errors, DecodeError{i, fmt.Errorf("no decoder for message type %d", m.Type)})
+			continue
+		}
+		decoded, err := decoder(m.Payload)
+		if err != nil {
+			set.Errors = append(set.Errors, DecodeError{i, err})
+			continue
+		}
+
+		if p, ok := decoded.(Positioned); ok {
+			set.Positions = append(set.Positions, p)
+			continue
+		}
+		switch m.Type {
+		case 5, 24:
+			set.Static = append(set.Static, decoded)
+		case 12:
+			set.Safety = append(set.Safety, decoded)
+		case 6, 8:
+			set.Binary = append(set.Binary, decoded)
+		default:
+			set.Other = append(set.Other, decoded)
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSupportedTypes(t *testing.T) {
+	want := []uint8{1, 2, 3, 4, 5, 6, 7, 8, 9, 11, 12, 13, 15, 16, 18, 19, 20, 21, 22, 23, 24, 27}
+
+	got := SupportedTypes()
+	if !reflect.DeepEqual(got, want) {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("SupportedTypes()")
+	}
+}
+
+func TestDecodeRaw(t *testing.T) {
+	classA, _ := DecodeClassAPositionReport("38u<a<?PAA2>P:WfuAO9PW<P0PuQ")
+	staticVoyage, _ := DecodeStaticVoyageData("533iFNT00003W;3G;384iT<T400000000000001?88?73v0ik0RC1H11H30H51CU0E2CkP0")
+
+	cases := []struct {
+		payload string
+		padding uint8
+		want    interface{}
+		ok      bool
+	}{
+		{"38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0, classA, true},
+		{"533iFNT00003W;3G;384iT<T400000000000001?88?73v0ik0RC1H11H30H51CU0E2CkP0", 2, staticVoyage, true},
+		{":000", 0, nil, false},                         // No decoder for type 10
+		{"38u<a<?PAA2>P:WfuAO9PW<P0Pu~", 0, nil, false}, // Outside the armoring alphabet
+		{"38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 6, nil, false},
+		{"B", 0, nil, false}, // Truncated type 18
+		{"38u<a<?PAA2>P:WfuAO9PW<P0Pu", 0, nil, false},
+		{"", 0, nil, false},
+	}
+	for _, c := range cases {
+		got, err := DecodeRaw(c.payload, c.padding)
+		if (err == nil) != c.ok || !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want, c.ok)
+			t.Errorf("DecodeRaw(payload string, padding uint8) for %q", c.payload)
+		}
+	}
+}
+
+func TestDecodeAll(t *testing.T) {
+	messages := []*Message{
+		{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0},
+		{5, "533iFNT00003W;3G;384iT<T400000000000001?88?73v0ik0RC1H11H30H51CU0E2CkP0", 2},
+		{4, "402R3KiutR0Qk156V4QQTOA00<0;", 0},
+		{7, "7000", 0},
+		{12, "<02:oP0kKcv0@<51C5PB5@?BDPD?P:?2?EB7PDB16693P381>>5<PikP", 0},
+		{8, "85Mwom1KfI?GR<NgcvM1Hg<P2FaGjRN<S22j;WN:IDle3f5Qsq6=620c;<gvsa8P?;j>Nl0oKaCLIdeFlr<Gh@Jc95:i>c0", 2},
+		{20, "Dh3OvjB8IN>4", 0},
+		{5, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}, // Type doesn't match the payload
+		nil,
+		{24, "H42O55i18tMET00000000000000", 2},
+		{18, "B", 0}, // Truncated
+	}
+
+	set := DecodeAll(messages)
+	got := []int{len(set.Positions), len(set.Static), len(set.Safety), len(set.Binary), len(set.Other)}
+	want := []int{2, 2, 1, 1, 1}
+	if !reflect.DeepEqual(got, want) {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeAll(messages []*Message)")
+	}
+
+	if _, ok := set.Positions[1].(BaseStationReport); !ok {
+		t.Errorf("DecodeAll(messages []*Message) should keep the order of the messages")
+	}
+
+	var errIndexes []int
+	for _, e := range set.Errors {
+		errIndexes = append(errIndexes, e.Index)
+	}
+	if !reflect.DeepEqual(errIndexes, []int{3, 7, 8, 10}) {
+		fmt.Println("Got : ", errIndexes)
+		fmt.Println("Want: ", []int{3, 7, 8, 10})
+		t.Errorf("DecodeAll(messages []*Message) errors")
+	}
+
+	if err := set.Errors[3].Err; !errors.Is(err, ErrMalformed) {
+		t.Errorf("DecodeAll(messages []*Message): truncated message error %v should wrap ErrMalformed", err)
+	}
+
+	wantWarnings := []LengthWarning{{3, 24, 72, 168}, {7, 168, 424, 424}, {10, 6, 168, 168}}
+	if !reflect.DeepEqual(set.Warnings, wantWarnings) {
+		fmt.Println("Got : ", set.Warnings)
+		fmt.Println("Want: ", wantWarnings)
+		t.Errorf("DecodeAll(messages []*Message) warnings")
+	}
+}
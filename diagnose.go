@@ -0,0 +1,136 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Diagnosis describes everything that can be told about a sentence, valid or not, so that one
+// can find out why a feed "doesn't work". The envelope fields are set as far as they could be
+// parsed. Problems lists what looks wrong with the sentence in human readable form, including
+// things Router tolerates with the default options, e.g a lower case checksum, an unknown message
+// type or a payload length that doesn't match the type. So an empty Problems means a clean
+// sentence, but a sentence with problems may still be accepted by Router.
+type Diagnosis struct {
+	Envelope
+	Delimiter        bool       // The sentence starts with ! or $
+	HasChecksum      bool       // The sentence carries a *HH checksum
+	ChecksumOK       bool       // The declared checksum matches the computed one
+	DeclaredChecksum string     // As found in the sentence
+	ComputedChecksum string     // Upper case hex
+	Source           SourceKind // From the talker ID, see SourceType
+	Type             uint8      // Message type, from the first payload character
+	Bits             int        // Payload length in bits, fill bits excluded
+	MinBits, MaxBits int        // Expected length of Type, see ExpectedBits
+	Problems         []string
+}
+
+// Diagnose checks a sentence end to end (delimiter, checksum, identifier, envelope fields, payload
+// and its length) and reports its findings. It never fails, every problem is reported in the
+// Diagnosis. It is meant for debugging, use Router or ParseEnvelope to process sentences.
+func Diagnose(sentence string) Diagnosis {
+	var d Diagnosis
+	problem := func(format string, a ...interface{}) {
+		d.Problems = append(d.Problems, fmt.Sprintf(format, a...))
+	}
+
+	if len(sentence) == 0 {
+		problem("empty line")
+		return d
+	}
+	if IsHeartbeat(sentence) {
+		problem("heartbeat or comment line")
+		return d
+	}
+
+	d.Delimiter = delimiterLength(sentence) == 1
+	if !d.Delimiter {
+		problem("no ! or $ delimiter")
+	}
+	body := sentence[delimiterLength(sentence):]
+
+	if i := strings.LastIndexByte(body, '*'); i >= 0 {
+		d.HasChecksum = true
+		d.DeclaredChecksum = body[i+1:]
+		body = body[:i]
+	}
+	d.ComputedChecksum = fmt.Sprintf("%02X", ComputeChecksum(body))
+	switch {
+	case !d.HasChecksum:
+		problem("no checksum, computed %s", d.ComputedChecksum)
+	case !strings.EqualFold(d.DeclaredChecksum, d.ComputedChecksum):
+		problem("checksum %q doesn't match computed %s", d.DeclaredChecksum, d.ComputedChecksum)
+	case !upperCaseChecksum(sentence):
+		d.ChecksumOK = true
+		problem("lower case checksum, NMEA 0183 requires upper case")
+	default:
+		d.ChecksumOK = true
+	}
+
+	tokens := strings.Split(body, ",")
+	if len(tokens[0]) >= 2 {
+		d.Talker = tokens[0][:2]
+		d.Source = SourceKinds[d.Talker]
+	}
+	if len(tokens[0]) == 5 {
+		d.Format = tokens[0][2:]
+	}
+	if len(tokens[0]) < 4 || !aisIdentifiers[tokens[0][:4]] {
+		problem("identifier %q isn't AIVDM/AIVDO", tokens[0])
+	}
+	if len(tokens) != 7 {
+		problem("%d fields, AIS sentences have 7", len(tokens))
+		return d
+	}
+
+	var err error
+	if d.FragmentCount, err = parseEnvelopeInt("fragment count", tokens[1], 1, 9); err != nil {
+		problem("%s", err)
+	} else if d.FragmentNumber, err = parseEnvelopeInt("fragment number", tokens[2], 1, d.FragmentCount); err != nil {
+		problem("%s", err)
+	}
+	d.SequentialID, d.Channel, d.Payload = tokens[3], tokens[4], tokens[5]
+	if d.FillBits, err = parseEnvelopeInt("fill bits", tokens[6], 0, 5); err != nil {
+		problem("%s", err)
+	}
+
+	if len(d.Payload) == 0 {
+		problem("empty payload")
+		return d
+	}
+	if _, err := ArmorDecode(d.Payload, d.FillBits); err != nil {
+		problem("%s", err)
+	}
+	d.Bits = len(d.Payload)*6 - d.FillBits
+
+	// Only the first fragment carries the type and the length is that of the whole message
+	if d.FragmentNumber > 1 {
+		return d
+	}
+	d.Type = MessageType(d.Payload)
+	var known bool
+	if d.MinBits, d.MaxBits, known = ExpectedBits(d.Type); !known {
+		problem("unknown message type %d", d.Type)
+	} else if d.FragmentCount == 1 && (d.Bits < d.MinBits || d.Bits > d.MaxBits) {
+		problem("length %d bits, type %d should be %d-%d", d.Bits, d.Type, d.MinBits, d.MaxBits)
+	}
+
+	return d
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	cases := []struct {
+		sentence string
+		want     Diagnosis
+	}{
+		{
+			"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F",
+			Diagnosis{
+				Envelope: Envelope{Talker: "AI", Format: "VDM", FragmentCount: 1, FragmentNumber: 1,
+					Channel: "B", Payload: "38u<a<?PAA2>P:WfuAO9PW<P0PuQ"},
+				Delimiter: true, HasChecksum: true, ChecksumOK: true, DeclaredChecksum: "6F", ComputedChecksum: "6F",
+				Source: SourceMobile, Type: 3, Bits: 168, MinBits: 168, MaxBits: 168,
+			},
+		},
+		{
+			"AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0Pu,0*6f",
+			Diagnosis{
+				Envelope: Envelope{Talker: "AI", Format: "VDM", FragmentCount: 1, FragmentNumber: 1,
+					Channel: "B", Payload: "38u<a<?PAA2>P:WfuAO9PW<P0Pu"},
+				HasChecksum: true, DeclaredChecksum: "6f", ComputedChecksum: "3E",
+				Source: SourceMobile, Type: 3, Bits: 162, MinBits: 168, MaxBits: 168,
+				Problems: []string{
+					"no ! or $ delimiter",
+					"checksum \"6f\" doesn't match computed 3E",
+					"length 162 bits, type 3 should be 168-168",
+				},
+			},
+		},
+		{
+			"!GPVDM,2,3,5,A,51CU0E2CkP0,7",
+			Diagnosis{
+				Envelope: Envelope{Talker: "GP", Format: "VDM", FragmentCount: 2,
+					SequentialID: "5", Channel: "A", Payload: "51CU0E2CkP0"},
+				Delimiter: true, ComputedChecksum: "17", Type: 5, Bits: 66, MinBits: 424, MaxBits: 424,
+				Problems: []string{
+					"no checksum, computed 17",
+					"identifier \"GPVDM\" isn't AIVDM/AIVDO",
+					"malformed sentence: fragment number \"3\"",
+					"malformed sentence: fill bits \"7\"",
+				},
+			},
+		},
+		{
+			"!AIVDM,1,1",
+			Diagnosis{
+				Envelope:  Envelope{Talker: "AI", Format: "VDM"},
+				Delimiter: true, ComputedChecksum: "57",
+				Source:   SourceMobile,
+				Problems: []string{"no checksum, computed 57", "3 fields, AIS sentences have 7"},
+			},
+		},
+		{"", Diagnosis{Problems: []string{"empty line"}}},
+		{"# comment", Diagnosis{Problems: []string{"heartbeat or comment line"}}},
+	}
+	for _, c := range cases {
+		got := Diagnose(c.sentence)
+		if !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("Diagnose(sentence string) for %q", c.sentence)
+		}
+	}
+}
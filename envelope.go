@@ -0,0 +1,195 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An Envelope is the NMEA 0183 part of an AIS sentence: where it came from, which fragment of a
+// message it is and the payload it carries, still armored. It is useful when you want to handle
+// the payload with your own tools, or elsewhere.
+type Envelope struct {
+	Talker         string // Talker ID, e.g AI for mobile stations, BS for base stations
+	Format         string // VDM for messages from other stations, VDO for own station
+	FragmentCount  int
+	FragmentNumber int
+	SequentialID   string // Set only for multi-sentence messages, may be empty
+	Channel        string // Radio channel, A or B. May be empty or 1/2 for some receivers
+	Payload        string
+	FillBits       int
+}
+
+// ParseEnvelope parses an AIS sentence and returns its envelope. It checks the checksum and the
+// AIS identifier but it does not reassemble multi-sentence messages or decode the payload.
+// It accepts the same options as Router.
+func ParseEnvelope(sentence string, options ...RouterOption) (Envelope, error) {
+	var e Envelope
+	var err error
+	o := newRouterOptions(options)
+
+	if len(sentence) == 0 {
+		return e, ErrEmpty
+	}
+	if IsHeartbeat(sentence) {
+		return e, ErrHeartbeat
+	}
+
+	if !o.checksumCheck(sentence) {
+		return e, ErrChecksum
+	}
+
+	// Some sources omit the delimiter, the identifier starts at the first character then
+	start := delimiterLength(sentence)
+	end := len(sentence)
+	if hasChecksum(sentence) {
+		end -= 3
+	}
+	tokens := strings.Split(sentence[start:end], ",")
+	if len(tokens) != 7 || len(tokens[0]) != 5 {
+		return e, ErrMalformed
+	}
+
+	if !o.identifiers[tokens[0][:4]] {
+		return e, ErrNotAIS
+	}
+	e.Talker = tokens[0][:2]
+	e.Format = tokens[0][2:5]
+
+	if e.FragmentCount, err = parseEnvelopeInt("fragment count", tokens[1], 1, 9); err != nil {
+		return e, err
+	}
+	if e.FragmentNumber, err = parseEnvelopeInt("fragment number", tokens[2], 1, e.FragmentCount); err != nil {
+		return e, err
+	}
+	e.SequentialID = tokens[3]
+	e.Channel = tokens[4]
+	e.Payload = tokens[5]
+	if len(e.Payload) == 0 {
+		return e, ErrMalformed
+	}
+	if e.FillBits, err = parseEnvelopeInt("fill bits", tokens[6], 0, 5); err != nil {
+		return e, err
+	}
+
+	return e, nil
+}
+
+// parseEnvelopeInt parses the integer field name of a sentence and checks that it is between min
+// and max. Errors wrap ErrMalformed and name the field, e.g "malformed sentence: fragment count "X"".
+func parseEnvelopeInt(name, field string, min, max int) (int, error) {
+	n, err := strconv.Atoi(field)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%w: %s %q", ErrMalformed, name, field)
+	}
+	return n, nil
+}
+
+// A SourceKind is the kind of station that received or transmitted an AIS sentence, as told
+// by the talker ID of the sentence.
+type SourceKind uint8
+
+// Source kinds
+const (
+	SourceUnknown SourceKind = iota
+	SourceMobile
+	SourceBaseStation
+	SourceSatellite
+	SourceRepeater
+	SourceLimited
+	SourceAidToNavigation
+)
+
+// String returns a description of the source kind
+func (k SourceKind) String() string {
+	switch k {
+	case SourceMobile:
+		return "mobile station"
+	case SourceBaseStation:
+		return "base station"
+	case SourceSatellite:
+		return "satellite"
+	case SourceRepeater:
+		return "repeater"
+	case SourceLimited:
+		return "limited base station"
+	case SourceAidToNavigation:
+		return "aid to navigation"
+	}
+	return "unknown"
+}
+
+// SourceKinds maps the talker IDs of AIS sentences (IEC 61162-1) to their source kind.
+// Satellite feeds have no standard talker ID, so none maps to SourceSatellite by default. If your
+// provider uses a distinct one, add it here.
+var SourceKinds = map[string]SourceKind{
+	"AI": SourceMobile,
+	"AT": SourceMobile,
+	"AB": SourceBaseStation,
+	"AD": SourceBaseStation,
+	"AR": SourceBaseStation,
+	"BS": SourceBaseStation,
+	"SA": SourceBaseStation,
+	"AS": SourceLimited,
+	"AX": SourceRepeater,
+	"AN": SourceAidToNavigation,
+}
+
+// SourceType returns the kind of source of an AIS sentence from its talker ID (the two characters
+// after the delimiter, e.g AI for !AIVDM). Analysts merging feeds can use it to weight or filter
+// sentences by source. It doesn't validate the sentence, unknown talkers are SourceUnknown.
+// For a parsed Envelope, SourceKinds[e.Talker] gives the same result.
+func SourceType(sentence string) SourceKind {
+	start := delimiterLength(sentence)
+	if len(sentence) < start+2 {
+		return SourceUnknown
+	}
+	return SourceKinds[sentence[start:start+2]]
+}
+
+// PeekType returns the message type of an AIS sentence from the first character of its payload,
+// without parsing the whole sentence. It is meant for fast pre-filtering of high volume feeds,
+// before the expensive path of Router. PeekType does not validate the checksum nor the AIS
+// identifier, so a true result doesn't mean the sentence is valid. It returns false when the
+// sentence is a continuation fragment (only the first fragment carries the type) or when it
+// can't find a payload.
+func PeekType(sentence string) (uint8, bool) {
+	field := 0
+	fieldStart := 0
+	for i := 0; i < len(sentence); i++ {
+		if sentence[i] != ',' {
+			continue
+		}
+		field++
+		switch field {
+		case 3: // just finished the fragment number
+			if sentence[fieldStart:i] != "1" {
+				return 0, false
+			}
+		case 5: // the payload follows
+			if i+1 < len(sentence) && sentence[i+1] != ',' {
+				return decodeAisChar(sentence[i+1]), true
+			}
+			return 0, false
+		}
+		fieldStart = i + 1
+	}
+	return 0, false
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvelope(t *testing.T) {
+	cases := []struct {
+		sentence string
+		want     Envelope
+		fail     bool
+	}{
+		{
+			"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F",
+			Envelope{Talker: "AI", Format: "VDM", FragmentCount: 1, FragmentNumber: 1, SequentialID: "",
+				Channel: "B", Payload: "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", FillBits: 0},
+			false,
+		},
+		{
+			"!AIVDM,2,2,5,A,51CU0E2CkP0,2*0C",
+			Envelope{Talker: "AI", Format: "VDM", FragmentCount: 2, FragmentNumber: 2, SequentialID: "5",
+				Channel: "A", Payload: "51CU0E2CkP0", FillBits: 2},
+			false,
+		},
+		{
+			"AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", // no delimiter
+			Envelope{Talker: "AI", Format: "VDM", FragmentCount: 1, FragmentNumber: 1, SequentialID: "",
+				Channel: "B", Payload: "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", FillBits: 0},
+			false,
+		},
+		{"!AIVDM,2,2,5,A,51CU0E2CkP0,2*0D", Envelope{}, true},          // bad checksum
+		{"$GPGLL,4916.45,N,12311.12,W,225444,A,*1D", Envelope{}, true}, // not AIS
+		{"", Envelope{}, true},
+	}
+	for _, c := range cases {
+		got, err := ParseEnvelope(c.sentence)
+		if got != c.want || (err != nil) != c.fail {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("ParseEnvelope(sentence string)")
+		}
+	}
+}
+
+func TestWithIdentifiers(t *testing.T) {
+	sentence := "!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F"
+	if _, err := ParseEnvelope(sentence, WithIdentifiers(map[string]bool{"XXVD": true})); err == nil {
+		t.Errorf("WithIdentifiers(set map[string]bool): replaced identifier still accepted")
+	}
+
+	extended := AISIdentifiers()
+	extended["XXVD"] = true
+	sentence = "!XXVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*67"
+	if _, err := ParseEnvelope(sentence); err == nil {
+		t.Errorf("ParseEnvelope(sentence string): unknown identifier accepted")
+	}
+	if _, err := ParseEnvelope(sentence, WithIdentifiers(extended)); err != nil {
+		t.Errorf("WithIdentifiers(set map[string]bool): extended identifier rejected: %s", err)
+	}
+	if _, err := Router(sentence, WithIdentifiers(extended)); err != nil {
+		t.Errorf("Router(sentence string, options ...RouterOption): extended identifier rejected: %s", err)
+	}
+	if aisIdentifiers["XXVD"] {
+		t.Errorf("AISIdentifiers(): default set was modified")
+	}
+}
+
+func TestRequireChecksum(t *testing.T) {
+	cases := []struct {
+		sentence string
+		require  bool
+		err      error
+	}{
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0", true, ErrChecksum},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0", false, nil},
+		{"AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0", false, nil},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", false, nil},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6E", false, ErrChecksum}, // Present checksums are verified
+	}
+	for _, c := range cases {
+		if _, err := Router(c.sentence, RequireChecksum(c.require)); err != c.err {
+			fmt.Println("Got : ", err)
+			fmt.Println("Want: ", c.err)
+			t.Errorf("Router(sentence string, options ...RouterOption) with RequireChecksum(%t) for %q", c.require, c.sentence)
+		}
+		e, err := ParseEnvelope(c.sentence, RequireChecksum(c.require))
+		if err != c.err || (err == nil && (e.Payload != "38u<a<?PAA2>P:WfuAO9PW<P0PuQ" || e.FillBits != 0)) {
+			fmt.Println("Got : ", e, err)
+			fmt.Println("Want: ", c.err)
+			t.Errorf("ParseEnvelope(sentence string, options ...RouterOption) with RequireChecksum(%t) for %q", c.require, c.sentence)
+		}
+	}
+}
+
+func TestEnvelopeIntegerFields(t *testing.T) {
+	cases := []struct {
+		sentence string
+		field    string // Named in the error, "" if the sentence is valid
+	}{
+		{"!AIVDM,X,1,,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*05", "fragment count"},
+		{"!AIVDM,0,1,,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6D", "fragment count"},
+		{"!AIVDM,-2,1,,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*42", "fragment count"},
+		{"!AIVDM,2,Y,1,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*36", "fragment number"},
+		{"!AIVDM,2,3,1,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*5C", "fragment number"},
+		{"!AIVDM,1,X,,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*05", "fragment number"},
+		{"!AIVDM,1,7,,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6A", "fragment number"},
+		{"!AIVDM,1,1,,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,Z*06", "fill bits"},
+		{"!AIVDM,1,1,,A,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,6*6A", "fill bits"},
+		{"!AIVDM,1,1,,A,B,2*66", ""},
+	}
+	for _, c := range cases {
+		_, routerErr := Router(c.sentence)
+		_, envelopeErr := ParseEnvelope(c.sentence)
+		for i, err := range []error{routerErr, envelopeErr} {
+			if c.field == "" {
+				if err != nil {
+					t.Errorf("Router/ParseEnvelope (%d) for %q: unexpected error %s", i, c.sentence, err)
+				}
+				continue
+			}
+			if !errors.Is(err, ErrMalformed) || !strings.Contains(err.Error(), c.field) {
+				fmt.Println("Got : ", err)
+				fmt.Println("Want: ", ErrMalformed, c.field)
+				t.Errorf("Router/ParseEnvelope (%d) for %q", i, c.sentence)
+			}
+		}
+	}
+
+	// Fill bits are taken from the sentence, for single sentence messages too
+	if m, err := Router("!AIVDM,1,1,,A,B,2*66"); err != nil || m.Padding != 2 {
+		t.Errorf("Router(sentence string, options ...RouterOption): fill bits not set")
+	}
+}
+
+func TestRouterEmptyPayload(t *testing.T) {
+	cases := []struct {
+		sentence string
+		want     *Message
+		err      error
+	}{
+		{"!AIVDM,1,1,,A,,0*26", nil, ErrMalformed},
+		{"!AIVDM,2,1,3,A,,0*16", nil, ErrMalformed},
+		{"!AIVDM,1,1,,A,B,0*64", &Message{18, "B", 0}, nil},
+		{"!AIVDM,1,1,,A*16", nil, ErrMalformed},
+	}
+	for _, c := range cases {
+		got, err := Router(c.sentence)
+		if err != c.err || (got == nil) != (c.want == nil) || (got != nil && *got != *c.want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want, c.err)
+			t.Errorf("Router(sentence string, options ...RouterOption)")
+		}
+		if _, err := ParseEnvelope(c.sentence); err != c.err {
+			fmt.Println("Got : ", err)
+			fmt.Println("Want: ", c.err)
+			t.Errorf("ParseEnvelope(sentence string, options ...RouterOption)")
+		}
+	}
+	if MessageType("") != 0 {
+		t.Errorf("MessageType(payload string)")
+	}
+}
+
+func TestFailureReason(t *testing.T) {
+	cases := []struct {
+		sentence string
+		want     string
+	}{
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", ""},
+		{"", "empty"},
+		{"# keep-alive", "heartbeat"},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6E", "checksum"},
+		{"$GPGLL,4916.45,N,12311.12,W,225444,A,*1D", "not-ais"},
+		{"!AIVDM,1,1,,A,,0*26", "malformed"},
+		{"!AIVDM,2,2,3,B,1@0000000000000,2*55", "multipart"},
+	}
+	for _, c := range cases {
+		_, err := Router(c.sentence)
+		if got := FailureReason(err); got != c.want {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("FailureReason(err error) for %q", c.sentence)
+		}
+	}
+	if got := FailureReason(errors.New("unexpected")); got != "other" {
+		t.Errorf("FailureReason(err error) for other errors")
+	}
+}
+
+func TestPeekType(t *testing.T) {
+	cases := []struct {
+		sentence string
+		mType    uint8
+		ok       bool
+	}{
+		{"!AIVDM,1,1,,A,14eGrSPP00ncMJTO5C6aBwvP2D0?,0*7A", 1, true},
+		{"!AIVDM,1,1,,B,;4R33:1uUK2F`q?mOt@@GoQ00000,0*5D", 11, true},
+		{"!AIVDM,2,1,3,B,55P5TL01VIaAL@7WKO@mBplU@<PDhh000000001S;AJ::4A80?4i@E53,0*3E", 5, true},
+		{"!AIVDM,2,2,3,B,1@0000000000000,2*55", 0, false},
+		{"!AIVDM,1,1,,A,,0*26", 0, false},
+		{"!AIVDM,1,1", 0, false},
+		// PeekType doesn't validate the checksum
+		{"!AIVDM,1,1,,A,14eGrSPP00ncMJTO5C6aBwvP2D0?,0*00", 1, true},
+	}
+	for _, c := range cases {
+		mType, ok := PeekType(c.sentence)
+		if mType != c.mType || ok != c.ok {
+			fmt.Println("Got : ", mType, ok)
+			fmt.Println("Want: ", c.mType, c.ok)
+			t.Errorf("PeekType(sentence string)")
+		}
+	}
+}
+
+func BenchmarkParseEnvelope(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ParseEnvelope("!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F")
+	}
+}
+
+func BenchmarkPeekType(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PeekType("!AIVDM,1,1,,A,14eGrSPP00ncMJTO5C6aBwvP2D0?,0*7A")
+	}
+}
+
+func TestSourceType(t *testing.T) {
+	cases := []struct {
+		sentence string
+		want     SourceKind
+	}{
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", SourceMobile},
+		{"AIVDO,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", SourceMobile},
+		{"!ABVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", SourceBaseStation},
+		{"!BSVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", SourceBaseStation},
+		{"!ASVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", SourceLimited},
+		{"!AXVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", SourceRepeater},
+		{"!ANVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", SourceAidToNavigation},
+		{"$GPGLL,4916.45,N,12311.12,W,225444,A,*1D", SourceUnknown},
+		{"!A", SourceUnknown},
+		{"", SourceUnknown},
+	}
+	for _, c := range cases {
+		if got := SourceType(c.sentence); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("SourceType(sentence string) for %q", c.sentence)
+		}
+	}
+}
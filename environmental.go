@@ -0,0 +1,138 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import "errors"
+
+// Environmental is a decoded Environmental message (DAC 1, FI 26) of IMO SN.1/Circ.289. It carries
+// one to eight sensor reports of 112 bits, each about one kind of measurement of a site.
+type Environmental struct {
+	Reports []EnvironmentalReport
+}
+
+// An EnvironmentalReport is one sensor report of an Environmental message. Only the fields
+// relevant to its Type are set, for the types we don't decode (3D and horizontal current flow,
+// sea state, salinity, weather and air gap) only the header is. Like in MetHydro, measurements
+// that aren't available are NaN and directions keep their not available value.
+type EnvironmentalReport struct {
+	Type              uint8   // Report type (enumeration at EnvironmentalReportTypes)
+	Day               uint8   // UTC, 0 means not available
+	Hour              uint8   // UTC, 24 means not available
+	Minute            uint8   // UTC, 60 means not available
+	SiteID            uint8   // Links the reports of the same site
+	Lon               float64 // Site location
+	Lat               float64 // Site location
+	Altitude          float32 // Site location, meters
+	Owner             uint8   // Site location, sensor owner
+	Timeout           uint8   // Site location, data timeout
+	Name              string  // Station ID
+	WindSpeed         float32 // Wind, knots
+	WindGust          float32 // Wind, knots
+	WindDirection     uint16  // Wind, degrees, 360 means not available
+	WindGustDirection uint16  // Wind, degrees, 360 means not available
+	WaterLevelType    uint8   // Water level, 0 deviation from the datum, 1 water depth
+	WaterLevel        float32 // Water level, meters
+	WaterLevelTrend   uint8   // Water level, 0 steady, 1 decreasing, 2 increasing, 3 not available
+	VerticalDatum     uint8   // Water level, reference datum
+	Currents          [3]MetHydroCurrent
+	SensorType        uint8 // Wind, water level and current flow
+}
+
+// Environmental sensor report types
+var EnvironmentalReportTypes = [...]string{
+	"Site location", "Station ID", "Wind", "Water level", "Current flow (2D)", "Current flow (3D)",
+	"Horizontal current flow", "Sea state", "Salinity", "Weather", "Air gap/Air draft",
+	"reserved", "reserved", "reserved", "reserved", "reserved",
+}
+
+// Environmental sensor report type codes
+const (
+	EnvironmentalSiteLocation = iota
+	EnvironmentalStationID
+	EnvironmentalWind
+	EnvironmentalWaterLevel
+	EnvironmentalCurrent2D
+)
+
+// DecodeEnvironmental decodes the application specific data of an Environmental message
+// (DAC 1, FI 26). Each sensor report is 112 bits, so the number of reports is derived from the
+// data length.
+func DecodeEnvironmental(data []byte) (Environmental, error) {
+	var m Environmental
+
+	if len(data)*6 < 112 {
+		return m, errors.New("Environmental message is too short, it should carry at least one report.")
+	}
+
+	for first := 0; first+111 < len(data)*6 && len(m.Reports) < 8; first += 112 {
+		m.Reports = append(m.Reports, decodeEnvironmentalReport(first, data))
+	}
+
+	return m, nil
+}
+
+// decodeEnvironmentalReport decodes the 112 bit sensor report starting at bit first.
+func decodeEnvironmentalReport(first int, data []byte) EnvironmentalReport {
+	var r EnvironmentalReport
+
+	r.Type = uint8(bitsToInt(first, first+3, data))
+	r.Day = uint8(bitsToInt(first+4, first+8, data))
+	r.Hour = uint8(bitsToInt(first+9, first+13, data))
+	r.Minute = uint8(bitsToInt(first+14, first+19, data))
+	r.SiteID = uint8(bitsToInt(first+20, first+26, data))
+
+	var raw int32
+	switch r.Type {
+	case EnvironmentalSiteLocation:
+		r.Lon, r.Lat = cbnCoordinates(first+27, data)
+		raw = int32(bitsToInt(first+82, first+92, data))
+		r.Altitude = metValue(raw, raw != 2047, 10, 0)
+		r.Owner = uint8(bitsToInt(first+93, first+96, data))
+		r.Timeout = uint8(bitsToInt(first+97, first+99, data))
+		// Bits 100-111 are spare
+	case EnvironmentalStationID:
+		r.Name = bitsToString(first+27, first+110, data)
+	case EnvironmentalWind:
+		raw = int32(bitsToInt(first+27, first+33, data))
+		r.WindSpeed = metValue(raw, raw != 127, 1, 0)
+		raw = int32(bitsToInt(first+34, first+40, data))
+		r.WindGust = metValue(raw, raw != 127, 1, 0)
+		r.WindDirection = uint16(bitsToInt(first+41, first+49, data))
+		r.WindGustDirection = uint16(bitsToInt(first+50, first+58, data))
+		r.SensorType = uint8(bitsToInt(first+59, first+61, data))
+		// Bits 62-108 are the wind forecast
+	case EnvironmentalWaterLevel:
+		r.WaterLevelType = uint8(bitsToInt(first+27, first+27, data))
+		raw = bitsToSignedInt(first+28, first+43, data)
+		r.WaterLevel = metValue(raw, raw != -32768, 100, 0)
+		r.WaterLevelTrend = uint8(bitsToInt(first+44, first+45, data))
+		r.VerticalDatum = uint8(bitsToInt(first+46, first+50, data))
+		r.SensorType = uint8(bitsToInt(first+51, first+53, data))
+		// Bits 54-94 are the water level forecast
+	case EnvironmentalCurrent2D:
+		for i := range r.Currents {
+			c := first + 27 + 26*i
+			r.Currents[i] = decodeMetHydroCurrent(c, -1, data)
+			raw = int32(bitsToInt(c+17, c+25, data))
+			r.Currents[i].Depth = metValue(raw, raw != 511, 1, 0)
+		}
+		r.SensorType = uint8(bitsToInt(first+105, first+107, data))
+	}
+
+	return r
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeEnvironmental(t *testing.T) {
+	header := func(reportType int) string {
+		return bitField(int64(reportType), 4) + bitField(14, 5) + bitField(9, 5) + bitField(30, 6) + bitField(17, 7)
+	}
+	pad := func(bits string) string { return bits + strings.Repeat("0", 112-len(bits)) }
+
+	// Site location, written out field by field after IMO SN.1/Circ.289 rather than with the
+	// helpers: header, lon -70.5, lat -33.25, altitude 12.5 m (11 bits), owner 3, timeout 5, spare
+	location := "0000" + "01110" + "01001" + "011110" + "0010001" +
+		"1101011110101000110110100000" + "110110011111001011001010000" +
+		"00001111101" + "0011" + "101" + "000000000000"
+	station := pad(header(1) + sixBitText("PIRAEUS TIDE", 14))
+	wind := pad(header(2) + bitField(12, 7) + bitField(127, 7) + bitField(275, 9) + bitField(360, 9) + bitField(1, 3))
+	level := pad(header(3) + "0" + bitField(-35, 16) + bitField(1, 2) + bitField(4, 5) + bitField(2, 3))
+	current := pad(header(4) + bitField(12, 8) + bitField(90, 9) + bitField(0, 9) +
+		bitField(8, 8) + bitField(95, 9) + bitField(5, 9) + bitField(255, 8) + bitField(360, 9) + bitField(511, 9) +
+		bitField(3, 3))
+	weather := pad(header(9) + bitField(201, 11))
+
+	common := EnvironmentalReport{Day: 14, Hour: 9, Minute: 30, SiteID: 17}
+	want := make([]EnvironmentalReport, 6)
+	for i := range want {
+		want[i] = common
+	}
+	want[0].Lon, want[0].Lat, want[0].Altitude, want[0].Owner, want[0].Timeout = -70.5, -33.25, 12.5, 3, 5
+	want[1].Type, want[1].Name = 1, "PIRAEUS TIDE"
+	want[2].Type, want[2].WindSpeed, want[2].WindDirection, want[2].WindGustDirection, want[2].SensorType =
+		2, 12, 275, 360, 1
+	want[3].Type, want[3].WaterLevel, want[3].WaterLevelTrend, want[3].VerticalDatum, want[3].SensorType =
+		3, -0.35, 1, 4, 2
+	want[4].Type, want[4].SensorType = 4, 3
+	want[4].Currents = [3]MetHydroCurrent{{1.2, 90, 0}, {0.8, 95, 5}, {0, 360, 0}}
+	want[5].Type = 9
+
+	if len(location) != 112 {
+		t.Fatalf("site location fixture is %d bits, want 112", len(location))
+	}
+
+	got, err := DecodeBinaryData(1, 26, []byte(armor(location+station+wind+level+current+weather)))
+	if err != nil {
+		t.Fatalf("DecodeBinaryData(dac uint16, fi uint8, data []byte): %s", err)
+	}
+	reports := got.(Environmental).Reports
+
+	// Not available measurements are NaN, check them and zero them for the comparison
+	nan := []*float32{&reports[2].WindGust, &reports[4].Currents[2].Speed, &reports[4].Currents[2].Depth}
+	for _, v := range nan {
+		if !math.IsNaN(float64(*v)) {
+			t.Errorf("DecodeEnvironmental(data []byte): not available measurement is %f, want NaN", *v)
+		}
+		*v = 0
+	}
+	if !reflect.DeepEqual(reports, want) {
+		fmt.Println("Got : ", reports)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeEnvironmental(data []byte)")
+	}
+
+	unknown, _ := DecodeEnvironmental([]byte(armor(location[:82] + "11111111111" + location[93:])))
+	if r := unknown.Reports[0]; !math.IsNaN(float64(r.Altitude)) || r.Owner != 3 || r.Timeout != 5 {
+		fmt.Println("Got : ", r.Altitude, r.Owner, r.Timeout)
+		fmt.Println("Want: ", math.NaN(), 3, 5)
+		t.Errorf("DecodeEnvironmental(data []byte) with altitude not available")
+	}
+
+	if _, err := DecodeEnvironmental([]byte(armor(location[:100]))); err == nil {
+		t.Errorf("DecodeEnvironmental(data []byte): expected error for short message")
+	}
+}
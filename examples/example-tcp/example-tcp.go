@@ -46,7 +46,16 @@ func main() {
 	send := make(chan string, 1024)
 	receive := make(chan ais.Message, 1024)
 	failed := make(chan ais.FailedSentence, 1024)
-	go ais.Router(send, receive, failed)
+	go func() {
+		for sentence := range send {
+			message, err := ais.Router(sentence)
+			if err != nil {
+				failed <- ais.FailedSentence{Sentence: sentence, Issue: err.Error()}
+				continue
+			}
+			receive <- *message
+		}
+	}()
 
 	// Create a handler-process that reads messages from router, decodes and saves the payload
 	seen := make(map[uint32]shipData)
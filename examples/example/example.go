@@ -36,7 +36,17 @@ func main() {
 
 	done := make(chan bool)
 
-	go ais.Router(send, receive, failed)
+	go func() {
+		for sentence := range send {
+			message, err := ais.Router(sentence)
+			if err != nil {
+				failed <- ais.FailedSentence{Sentence: sentence, Issue: err.Error()}
+				continue
+			}
+			receive <- *message
+		}
+		receive <- ais.Message{Type: 255} // No more sentences
+	}()
 
 	go func() {
 		var message ais.Message
@@ -0,0 +1,79 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import "errors"
+
+// ExtendedStaticVoyage is a decoded Extended Ship Static and Voyage Related Data message
+// (DAC 1, FI 24) of IMO SN.1/Circ.289. It supplements the static and voyage data of type 5
+// messages with data that port authorities need, e.g air draught and persons on board.
+// Stations may omit the trailing fields. Fields the message is too short to carry are zero,
+// which is their not available value.
+type ExtendedStaticVoyage struct {
+	LinkageID       uint16    // Message linkage ID
+	AirDraught      float32   // Meters (sc 1/10), 0 means not available
+	LastPort        string    // UN/LOCODE of the last port of call
+	NextPort        string    // UN/LOCODE of the next port of call
+	SecondNextPort  string    // UN/LOCODE of the second next port of call
+	EquipmentStatus [26]uint8 // AIS/SOLAS equipment status, two bits per equipment
+	IceClass        uint8     // 15 means not available
+	ShaftPower      uint32    // Shaft horse power, 262143 means not available
+	VHFChannel      uint16    // VHF working channel, ITU-R M.1084
+	LloydsShipType  string    // Lloyd's ship type
+	GrossTonnage    uint32    // 262143 means not available
+	Laden           uint8     // 0 not available, 1 laden, 2 ballast
+	HeavyFuelOil    uint8     // Heavy fuel oil bunker: 0 not available, 1 no, 2 yes
+	LightFuelOil    uint8     // Light fuel oil bunker: 0 not available, 1 no, 2 yes
+	Diesel          uint8     // Diesel oil bunker: 0 not available, 1 no, 2 yes
+	BunkerOil       uint16    // Total amount of bunker oil, tonnes, 16383 means not available
+	Persons         uint16    // Persons on board, 0 means not available, 8191 means 8191 or more
+}
+
+// DecodeExtendedStaticVoyage decodes the application specific data of an Extended Ship Static
+// and Voyage Related Data message (DAC 1, FI 24). The full message is 304 bits, it should carry
+// at least the ports of call (113 bits).
+func DecodeExtendedStaticVoyage(data []byte) (ExtendedStaticVoyage, error) {
+	var m ExtendedStaticVoyage
+
+	if len(data)*6 < 113 {
+		return m, errors.New("Extended Ship Static and Voyage Related Data message is too short.")
+	}
+
+	m.LinkageID = uint16(bitsToInt(0, 9, data))
+	m.AirDraught = float32(bitsToInt(10, 22, data)) / 10
+	m.LastPort = bitsToString(23, 52, data)
+	m.NextPort = bitsToString(53, 82, data)
+	m.SecondNextPort = bitsToString(83, 112, data)
+
+	for i := range m.EquipmentStatus {
+		m.EquipmentStatus[i] = uint8(bitsToInt(113+2*i, 114+2*i, data))
+	}
+	m.IceClass = uint8(bitsToInt(165, 168, data))
+	m.ShaftPower = bitsToInt(169, 186, data)
+	m.VHFChannel = uint16(bitsToInt(187, 198, data))
+	m.LloydsShipType = bitsToString(199, 240, data)
+	m.GrossTonnage = bitsToInt(241, 258, data)
+	m.Laden = uint8(bitsToInt(259, 260, data))
+	m.HeavyFuelOil = uint8(bitsToInt(261, 262, data))
+	m.LightFuelOil = uint8(bitsToInt(263, 264, data))
+	m.Diesel = uint8(bitsToInt(265, 266, data))
+	m.BunkerOil = uint16(bitsToInt(267, 280, data))
+	m.Persons = uint16(bitsToInt(281, 293, data))
+
+	return m, nil
+}
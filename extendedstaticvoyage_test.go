@@ -0,0 +1,64 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeExtendedStaticVoyage(t *testing.T) {
+	ports := bitField(12, 10) + bitField(452, 13) +
+		sixBitText("GRPIR", 5) + sixBitText("ITGOA", 5) + sixBitText("ESBCN", 5)
+	rest := strings.Repeat(bitField(1, 2), 26) + bitField(15, 4) + bitField(12000, 18) +
+		bitField(2016, 12) + sixBitText("A31A2GT", 7) + bitField(25000, 18) +
+		bitField(1, 2) + bitField(1, 2) + bitField(2, 2) + bitField(2, 2) + bitField(850, 14) +
+		bitField(23, 13) + bitField(0, 10)
+
+	short := ExtendedStaticVoyage{LinkageID: 12, AirDraught: 45.2, LastPort: "GRPIR", NextPort: "ITGOA",
+		SecondNextPort: "ESBCN"}
+	full := short
+	for i := range full.EquipmentStatus {
+		full.EquipmentStatus[i] = 1
+	}
+	full.IceClass, full.ShaftPower, full.VHFChannel, full.LloydsShipType = 15, 12000, 2016, "A31A2GT"
+	full.GrossTonnage, full.Laden, full.HeavyFuelOil, full.LightFuelOil, full.Diesel = 25000, 1, 1, 2, 2
+	full.BunkerOil, full.Persons = 850, 23
+
+	cases := []struct {
+		bits string
+		want ExtendedStaticVoyage
+	}{
+		{ports + rest, full},
+		{ports, short}, // Trailing fields omitted
+	}
+	for _, c := range cases {
+		got, err := DecodeBinaryData(1, 24, []byte(armor(c.bits)))
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeBinaryData(dac uint16, fi uint8, data []byte) for %d bits", len(c.bits))
+		}
+	}
+
+	if _, err := DecodeExtendedStaticVoyage([]byte(armor(ports[:100]))); err == nil {
+		t.Errorf("DecodeExtendedStaticVoyage(data []byte): expected error for short message")
+	}
+}
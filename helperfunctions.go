@@ -17,7 +17,10 @@
 
 package aislib
 
-import "strings"
+import (
+	"errors"
+	"strings"
+)
 
 // decodeAisChar takes a byte a returns the six bit field of AIS data.
 func decodeAisChar(character byte) byte {
@@ -28,8 +31,74 @@ func decodeAisChar(character byte) byte {
 	return character
 }
 
-// MessageType returns the type of an AIS message
+// encodeAisChar takes a six bit field and returns its armored AIS byte (the reverse of decodeAisChar).
+func encodeAisChar(sixbits byte) byte {
+	sixbits += 48
+	if sixbits > 87 {
+		sixbits += 8
+	}
+	return sixbits
+}
+
+// ArmorEncode packs the first bits bits of raw (most significant bit first) into an armored
+// AIS payload, six bits per character, and returns it with the number of fill bits that pad its
+// last character (see FillBits). It is the reverse of ArmorDecode. bits is capped to the length
+// of raw.
+func ArmorEncode(raw []byte, bits int) (payload string, fill int) {
+	if bits > len(raw)*8 {
+		bits = len(raw) * 8
+	}
+	if bits < 0 {
+		bits = 0
+	}
+
+	fill = FillBits(bits)
+	armored := make([]byte, (bits+fill)/6)
+	for i := range armored {
+		var sixbits byte
+		for b := 6 * i; b < 6*i+6; b++ {
+			sixbits <<= 1
+			if b < bits {
+				sixbits |= raw[b/8] >> uint(7-b%8) & 1
+			}
+		}
+		armored[i] = encodeAisChar(sixbits)
+	}
+	return string(armored), fill
+}
+
+// ArmorDecode unpacks an armored AIS payload into raw bytes, most significant bit first, dropping
+// the fill bits of its last character. The unused bits of the last byte are zero. It returns an
+// error if the payload has characters outside the AIS armoring alphabet or if fill isn't valid.
+func ArmorDecode(payload string, fill int) ([]byte, error) {
+	if fill < 0 || fill > 5 || fill > len(payload)*6 {
+		return nil, errors.New("Fill bits should be between 0 and 5.")
+	}
+
+	bits := len(payload)*6 - fill
+	raw := make([]byte, (bits+7)/8)
+	for i := 0; i < len(payload); i++ {
+		c := payload[i]
+		if c < '0' || c > 'w' || (c > 'W' && c < '`') {
+			return nil, errors.New("Payload has characters outside the AIS armoring alphabet.")
+		}
+		sixbits := decodeAisChar(c)
+		for j := 0; j < 6; j++ {
+			b := 6*i + j
+			if b >= bits {
+				break
+			}
+			raw[b/8] |= (sixbits >> uint(5-j) & 1) << uint(7-b%8)
+		}
+	}
+	return raw, nil
+}
+
+// MessageType returns the type of an AIS message, or 0 for an empty payload
 func MessageType(payload string) uint8 {
+	if len(payload) == 0 {
+		return 0
+	}
 	data := []byte(payload[:1])
 	return decodeAisChar(data[0])
 }
@@ -75,11 +144,39 @@ func bitsToInt(first, last int, payload []byte) uint32 {
 	return result
 }
 
+// bitsToSignedInt extracts certain bits from a payload and interprets them as a two's complement
+// signed integer, as AIS does for coordinates, rate of turn, etc.
+func bitsToSignedInt(first, last int, payload []byte) int32 {
+	shift := uint(31 - (last - first))
+	return int32(bitsToInt(first, last, payload)<<shift) >> shift
+}
+
+// shiftPayload returns the part of a payload starting at bit first, re-armored so that it starts at
+// bit 0. It is used to separate application specific data (binary messages) from their carrier
+// message, so they can be decoded the same way regardless of their carrier and its header size.
+// If the bits do not fill the last six bit character, its rightmost bits are zero.
+func shiftPayload(first int, payload []byte) []byte {
+	total := len(payload) * 6
+	if first >= total {
+		return []byte{}
+	}
+	data := make([]byte, (total-first+5)/6)
+	for i := range data {
+		start, end := first+6*i, first+6*i+5
+		if end < total {
+			data[i] = encodeAisChar(byte(bitsToInt(start, end, payload)))
+		} else {
+			data[i] = encodeAisChar(byte(bitsToInt(start, total-1, payload) << uint(end-total+1)))
+		}
+	}
+	return data
+}
+
 // bitsToString decodes text from an AIS payload. Text is packed in six bit ASCII
 func bitsToString(first, last int, payload []byte) string {
 	length := (last - first + 1) / 6 // How many characters we expect
 	start := first / 6               // At which byte the first character starts
-	var text [64]byte                // Not sure which the maximum text field size is, but this should be enough
+	var buf [64]byte                 // Enough for the fixed size fields, safety messages may need more
 	char := uint8(0)
 
 	// Some times we get truncated text fields. Since text fields have constant size,
@@ -93,12 +190,17 @@ func bitsToString(first, last int, payload []byte) string {
 		length = (len(payload)*6 - first) / 6
 	}
 
+	text := buf[:]
+	if length > len(buf) {
+		text = make([]byte, length)
+	}
+
 	remain := first % 6
 
 	// In this if/else there is some code duplication but I think the speed enhancement is worth it.
 	// The other way around would need 2*length branches. Now we have only 2.
 	// decodeAisChar function should be safe to use here since we check the payload's length
-	if remain < 6 {
+	if remain > 0 {
 		shiftLeftMost := uint8(remain + 2)
 		shiftRightMost := uint8(6 - remain)
 		for i := 0; i < length; i++ {
@@ -0,0 +1,155 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// bitField returns value as a two's complement binary string of width bits. It helps writing
+// test vectors field by field, the way the specifications describe them.
+func bitField(value int64, width int) string {
+	return fmt.Sprintf("%0*b", width, uint64(value)&(1<<uint(width)-1))
+}
+
+// armor packs a binary string (e.g made with bitField) into an AIS payload, padding the last
+// character with zeroes.
+func armor(bits string) string {
+	if len(bits)%6 != 0 {
+		bits += strings.Repeat("0", 6-len(bits)%6)
+	}
+	payload := make([]byte, len(bits)/6)
+	for i := range payload {
+		var sixbits byte
+		for _, b := range bits[6*i : 6*i+6] {
+			sixbits = sixbits<<1 | byte(b-'0')
+		}
+		payload[i] = encodeAisChar(sixbits)
+	}
+	return string(payload)
+}
+
+// payloadBits unpacks an AIS payload into a binary string, the reverse of armor.
+// It helps to alter fields of real world test vectors.
+func payloadBits(payload string) string {
+	bits := ""
+	for i := range payload {
+		bits += bitField(int64(decodeAisChar(payload[i])), 6)
+	}
+	return bits
+}
+
+// sixBitText encodes text as AIS six bit characters, padding it with @ to chars characters.
+func sixBitText(text string, chars int) string {
+	bits := ""
+	for i := 0; i < chars; i++ {
+		c := byte('@')
+		if i < len(text) {
+			c = text[i]
+		}
+		bits += bitField(int64(c&0x3F), 6)
+	}
+	return bits
+}
+
+func TestBitsToSignedInt(t *testing.T) {
+	cases := []struct {
+		bits string
+		want int32
+	}{
+		{bitField(5, 8), 5},
+		{bitField(-5, 8), -5},
+		{bitField(-128, 8), -128},
+		{bitField(-(37*60000 + 30000), 24), -(37*60000 + 30000)},
+		{bitField(-1, 28), -1},
+		{bitField(-1<<27, 28), -1 << 27},
+		{bitField(-54000000, 27), -54000000},
+		{bitField(-108000, 18), -108000},
+		{bitField(-1, 17), -1},
+	}
+	for _, c := range cases {
+		got := bitsToSignedInt(3, 2+len(c.bits), []byte(armor("101"+c.bits)))
+		if got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("bitsToSignedInt(first, last int, payload []byte)")
+		}
+	}
+}
+
+func TestArmorRoundTrip(t *testing.T) {
+	raw := []byte{0x12, 0x8F, 0xA3, 0x00, 0xFF, 0x5C, 0x71, 0xE0, 0x3B, 0xC4}
+	for bits := 0; bits <= len(raw)*8; bits++ {
+		payload, fill := ArmorEncode(raw, bits)
+		if fill != FillBits(bits) || len(payload)*6-fill != bits {
+			fmt.Println("Got : ", len(payload), fill)
+			fmt.Println("Want: ", (bits+FillBits(bits))/6, FillBits(bits))
+			t.Errorf("ArmorEncode(raw []byte, bits int) for %d bits", bits)
+			continue
+		}
+
+		got, err := ArmorDecode(payload, fill)
+		// The bits past bits are zero
+		want := make([]byte, (bits+7)/8)
+		copy(want, raw)
+		if bits%8 != 0 {
+			want[len(want)-1] &= 0xFF << uint(8-bits%8)
+		}
+		if err != nil || string(got) != string(want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", want)
+			t.Errorf("ArmorDecode(payload string, fill int) for %d bits", bits)
+		}
+	}
+}
+
+func TestArmorDecode(t *testing.T) {
+	// Type 1 header: type 1, repeat 0 and the first 4 bits of the MMSI
+	got, err := ArmorDecode("15M6", 0)
+	want := payloadBits("15M6")
+	gotBits := ""
+	for _, b := range got {
+		gotBits += bitField(int64(b), 8)
+	}
+	if err != nil || gotBits != want {
+		fmt.Println("Got : ", gotBits, err)
+		fmt.Println("Want: ", want)
+		t.Errorf("ArmorDecode(payload string, fill int)")
+	}
+
+	cases := []struct {
+		payload string
+		fill    int
+	}{
+		{"15M6", 6},
+		{"15M6", -1},
+		{"", 2},
+		{"15X6", 0}, // X-_ aren't used in the armoring
+		{"15M~", 0},
+	}
+	for _, c := range cases {
+		if _, err := ArmorDecode(c.payload, c.fill); err == nil {
+			t.Errorf("ArmorDecode(payload string, fill int): expected error for %q with %d fill bits", c.payload, c.fill)
+		}
+	}
+	if payload, fill := ArmorEncode([]byte{0xFF}, 16); payload != "wh" || fill != 4 {
+		t.Errorf("ArmorEncode(raw []byte, bits int) should cap bits to the length of raw, got %q %d", payload, fill)
+	}
+}
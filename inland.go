@@ -0,0 +1,157 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Here are the decoders for the application specific data of Inland AIS messages (DAC 200),
+// used by vessels on European inland waterways. Please have a look at the ECE/TRANS/SC.3/176
+// (Inland AIS) standard and http://catb.org/gpsd/AIVDM.html
+
+// An InlandStaticVoyage is an Inland ship static and voyage related data message (DAC 200, FI 10).
+// It complements the static voyage data (type 5) with the data inland vessels have to report.
+type InlandStaticVoyage struct {
+	ENI            string  // European Vessel Identification Number, 8 characters
+	Length         float32 // Meters, resolution 0.1m. 0 means not available
+	Beam           float32 // Meters, resolution 0.1m. 0 means not available
+	ShipType       uint16  // ERI ship or combination type (UN/ECE Recommendation 28)
+	Hazard         uint8   // Hazardous cargo (enumeration at InlandHazardousCargo)
+	Draught        float32 // Meters, resolution 0.01m. 0 means not available
+	Loaded         uint8   // 0 not available, 1 loaded, 2 unloaded
+	SpeedQuality   bool    // true if the speed comes from a certified device (high quality)
+	CourseQuality  bool    // true if the course comes from a certified device (high quality)
+	HeadingQuality bool    // true if the heading comes from a certified device (high quality)
+}
+
+// Inland hazardous cargo codes, the number of blue cones or lights the vessel displays
+var InlandHazardousCargo = [...]string{
+	"0 blue cones/lights", "1 blue cone/light", "2 blue cones/lights", "3 blue cones/lights",
+	"B-Flag", "Unknown", "not defined", "not defined",
+}
+
+// DecodeInlandStaticVoyage decodes the application specific data of an Inland ship static and
+// voyage related data message (DAC 200, FI 10). It is a broadcast message (type 8).
+func DecodeInlandStaticVoyage(data []byte) (InlandStaticVoyage, error) {
+	var m InlandStaticVoyage
+
+	if len(data)*6 < 104 {
+		return m, errors.New("Inland ship static and voyage related data message is too short.")
+	}
+
+	m.ENI = bitsToString(0, 47, data)
+	m.Length = float32(bitsToInt(48, 60, data)) / 10
+	m.Beam = float32(bitsToInt(61, 70, data)) / 10
+	m.ShipType = uint16(bitsToInt(71, 84, data))
+	m.Hazard = uint8(bitsToInt(85, 87, data))
+	m.Draught = float32(bitsToInt(88, 98, data)) / 100
+	m.Loaded = uint8(bitsToInt(99, 100, data))
+	m.SpeedQuality = cbnBool(101, data)
+	m.CourseQuality = cbnBool(102, data)
+	m.HeadingQuality = cbnBool(103, data)
+
+	return m, nil
+}
+
+// An InlandLocation identifies a lock, bridge or terminal of the inland waterways network by its
+// ISRS location code (UN country and location code, fairway section, terminal, hectometre).
+type InlandLocation struct {
+	Country        string // UN country code, 2 characters
+	Locode         string // UN/LOCODE, 3 characters
+	FairwaySection string // 5 characters
+	Terminal       string // 5 characters
+	Hectometre     string // Fairway hectometre, 5 characters
+}
+
+// An InlandETA is an ETA at lock/bridge/terminal message (DAC 200, FI 21). Vessels send it to
+// announce their arrival.
+type InlandETA struct {
+	InlandLocation
+	ETA        time.Time // UTC. Does not include year, like the ETA of type 5
+	Tugs       uint8     // Number of assisting tugboats, 7 means unknown
+	AirDraught float32   // Meters, resolution 0.01m. 0 means not available
+}
+
+// An InlandRTA is an RTA at lock/bridge/terminal message (DAC 200, FI 22). The shore station
+// replies to an InlandETA with the requested time of arrival.
+type InlandRTA struct {
+	InlandLocation
+	RTA    time.Time // UTC. Does not include year, like the ETA of type 5
+	Status uint8     // Lock/bridge/terminal status (enumeration at InlandRTAStatus)
+}
+
+// Lock/bridge/terminal status codes of RTA messages
+var InlandRTAStatus = [...]string{"Operational", "Limited operation", "Out of order", "not available"}
+
+// DecodeInlandETA decodes the application specific data of an ETA at lock/bridge/terminal message
+// (DAC 200, FI 21). It is an addressed message (type 6).
+func DecodeInlandETA(data []byte) (InlandETA, error) {
+	var m InlandETA
+
+	if len(data)*6 < 155 {
+		return m, errors.New("Inland ETA message is too short.")
+	}
+
+	m.InlandLocation = decodeInlandLocation(data)
+	m.ETA = decodeInlandTime(120, data)
+	m.Tugs = uint8(bitsToInt(140, 142, data))
+	m.AirDraught = float32(bitsToInt(143, 154, data)) / 100
+
+	return m, nil
+}
+
+// DecodeInlandRTA decodes the application specific data of an RTA at lock/bridge/terminal message
+// (DAC 200, FI 22). It is an addressed message (type 6).
+func DecodeInlandRTA(data []byte) (InlandRTA, error) {
+	var m InlandRTA
+
+	if len(data)*6 < 142 {
+		return m, errors.New("Inland RTA message is too short.")
+	}
+
+	m.InlandLocation = decodeInlandLocation(data)
+	m.RTA = decodeInlandTime(120, data)
+	m.Status = uint8(bitsToInt(140, 141, data))
+
+	return m, nil
+}
+
+// decodeInlandLocation decodes the location code (bits 0-119) of ETA and RTA messages.
+func decodeInlandLocation(data []byte) InlandLocation {
+	return InlandLocation{
+		Country:        bitsToString(0, 11, data),
+		Locode:         bitsToString(12, 29, data),
+		FairwaySection: bitsToString(30, 59, data),
+		Terminal:       bitsToString(60, 89, data),
+		Hectometre:     bitsToString(90, 119, data),
+	}
+}
+
+// decodeInlandTime decodes the 20 bit month, day, hour and minute field starting at bit first.
+func decodeInlandTime(first int, data []byte) time.Time {
+	month := uint8(bitsToInt(first, first+3, data))
+	day := uint8(bitsToInt(first+4, first+8, data))
+	hour := uint8(bitsToInt(first+9, first+13, data))
+	minute := uint8(bitsToInt(first+14, first+19, data))
+	timeString := fmt.Sprintf("%d/%d %d:%d", month, day, hour, minute)
+	t, _ := time.Parse("1/2 15:4", timeString)
+	return t
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDecodeInlandStaticVoyage(t *testing.T) {
+	data := armor(sixBitText("02324567", 8) + bitField(1105, 13) + bitField(114, 10) + bitField(8030, 14) +
+		bitField(2, 3) + bitField(285, 11) + bitField(1, 2) + "101" + bitField(0, 8))
+	want := InlandStaticVoyage{
+		ENI: "02324567", Length: 110.5, Beam: 11.4, ShipType: 8030, Hazard: 2, Draught: 2.85, Loaded: 1,
+		SpeedQuality: true, CourseQuality: false, HeadingQuality: true,
+	}
+
+	got, err := DecodeBinaryData(200, 10, []byte(data))
+	if err != nil || got != want {
+		fmt.Println("Got : ", got, err)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeInlandStaticVoyage(data []byte)")
+	}
+
+	if _, err := DecodeInlandStaticVoyage([]byte(data[:10])); err == nil {
+		t.Errorf("DecodeInlandStaticVoyage(data []byte)")
+	}
+}
+
+func TestDecodeInlandETARTA(t *testing.T) {
+	location := sixBitText("NL", 2) + sixBitText("RTM", 3) + sixBitText("12345", 5) +
+		sixBitText("ABC", 5) + sixBitText("00341", 5)
+	when := bitField(3, 4) + bitField(14, 5) + bitField(11, 5) + bitField(30, 6)
+	wantLocation := InlandLocation{
+		Country: "NL", Locode: "RTM", FairwaySection: "12345", Terminal: "ABC", Hectometre: "00341",
+	}
+	wantTime, _ := time.Parse("1/2 15:4", "3/14 11:30")
+
+	eta := armor(location + when + bitField(2, 3) + bitField(1250, 12) + bitField(0, 5))
+	wantETA := InlandETA{InlandLocation: wantLocation, ETA: wantTime, Tugs: 2, AirDraught: 12.5}
+	got, err := DecodeBinaryData(200, 21, []byte(eta))
+	if err != nil || got != wantETA {
+		fmt.Println("Got : ", got, err)
+		fmt.Println("Want: ", wantETA)
+		t.Errorf("DecodeInlandETA(data []byte)")
+	}
+
+	rta := armor(location + when + bitField(1, 2) + bitField(0, 2))
+	wantRTA := InlandRTA{InlandLocation: wantLocation, RTA: wantTime, Status: 1}
+	got, err = DecodeBinaryData(200, 22, []byte(rta))
+	if err != nil || got != wantRTA {
+		fmt.Println("Got : ", got, err)
+		fmt.Println("Want: ", wantRTA)
+		t.Errorf("DecodeInlandRTA(data []byte)")
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import "errors"
+
+// An Interrogation is a decoded AIS Interrogation (message type 15). A station requests up to
+// two message types from a first station and one from a second station.
+// Please have a look at http://catb.org/gpsd/AIVDM.html and ITU-R M.1371-5, 3.15.
+type Interrogation struct {
+	Repeat   uint8
+	MMSI     uint32
+	Requests []InterrogationRequest // One to three, in the order they are carried
+}
+
+// An InterrogationRequest is a message type requested from a station by an Interrogation.
+type InterrogationRequest struct {
+	DestMMSI uint32 // Interrogated station
+	Type     uint8  // Requested message type
+	Offset   uint16 // Slot offset of the response, 0 means the station chooses
+}
+
+// DecodeInterrogation decodes [the payload of] an AIS Interrogation (type 15). The message
+// is 88 bits with one request, 110 bits with two requests to the first station and 160 bits
+// when a second station is interrogated too. Only the requests the payload is long enough to
+// carry are returned. In the 160 bit form the second request of the first station is left out
+// if it is unused (message type 0).
+func DecodeInterrogation(payload string) (Interrogation, error) {
+	data := []byte(payload)
+	var m Interrogation
+
+	mType := decodeAisChar(data[0])
+	if mType != 15 {
+		return m, errors.New("Message isn't Interrogation (type 15).")
+	}
+	if len(data)*6 < 88 {
+		return m, errors.New("Interrogation is too short, it should carry at least one request.")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+
+	station1 := bitsToInt(40, 69, data)
+	m.Requests = append(m.Requests, decodeInterrogationRequest(station1, 70, data))
+
+	secondStation := len(data)*6 >= 158
+	if len(data)*6 >= 108 {
+		r := decodeInterrogationRequest(station1, 90, data)
+		if r.Type != 0 || !secondStation {
+			m.Requests = append(m.Requests, r)
+		}
+	}
+	if secondStation {
+		m.Requests = append(m.Requests, decodeInterrogationRequest(bitsToInt(110, 139, data), 140, data))
+	}
+
+	return m, nil
+}
+
+// decodeInterrogationRequest decodes the request starting at bit first, message type and slot offset.
+func decodeInterrogationRequest(dest uint32, first int, data []byte) InterrogationRequest {
+	return InterrogationRequest{
+		DestMMSI: dest,
+		Type:     uint8(bitsToInt(first, first+5, data)),
+		Offset:   uint16(bitsToInt(first+6, first+17, data)),
+	}
+}
+
+// RequestedTypes returns the message types requested, in the order of the requests. A type is
+// listed once per request, so it may appear twice if two stations are asked for it.
+func (m Interrogation) RequestedTypes() []uint8 {
+	types := make([]uint8, len(m.Requests))
+	for i, r := range m.Requests {
+		types[i] = r.Type
+	}
+	return types
+}
+
+// LongRange reports whether the interrogation requests a long-range position report (type 27),
+// e.g so that the type 27 responses of a satellite feed can be matched to it.
+func (m Interrogation) LongRange() bool {
+	for _, r := range m.Requests {
+		if r.Type == 27 {
+			return true
+		}
+	}
+	return false
+}
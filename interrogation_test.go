@@ -0,0 +1,86 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeInterrogation(t *testing.T) {
+	header := bitField(15, 6) + bitField(0, 2) + bitField(2470013, 30) + bitField(0, 2)
+	single := header + bitField(247123000, 30) + bitField(5, 6) + bitField(0, 12)
+	twoRequests := single + bitField(0, 2) + bitField(24, 6) + bitField(110, 12) + bitField(0, 2)
+	twoStations := twoRequests + bitField(247456000, 30) + bitField(5, 6) + bitField(0, 12) + bitField(0, 2)
+	unusedSecond := single + bitField(0, 2) + bitField(0, 6) + bitField(0, 12) +
+		bitField(0, 2) + bitField(247456000, 30) + bitField(5, 6) + bitField(0, 12) + bitField(0, 2)
+
+	cases := []struct {
+		bits string
+		want Interrogation
+	}{
+		{single, Interrogation{MMSI: 2470013, Requests: []InterrogationRequest{
+			{247123000, 5, 0},
+		}}},
+		{twoRequests, Interrogation{MMSI: 2470013, Requests: []InterrogationRequest{
+			{247123000, 5, 0}, {247123000, 24, 110},
+		}}},
+		{twoStations, Interrogation{MMSI: 2470013, Requests: []InterrogationRequest{
+			{247123000, 5, 0}, {247123000, 24, 110}, {247456000, 5, 0},
+		}}},
+		{unusedSecond, Interrogation{MMSI: 2470013, Requests: []InterrogationRequest{
+			{247123000, 5, 0}, {247456000, 5, 0},
+		}}},
+	}
+	for _, c := range cases {
+		got, err := DecodeInterrogation(armor(c.bits))
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeInterrogation(payload string) for %d bits", len(c.bits))
+		}
+	}
+
+	if _, err := DecodeInterrogation(armor(header)); err == nil {
+		t.Errorf("DecodeInterrogation(payload string): expected error for short message")
+	}
+	if _, err := DecodeInterrogation(armor(bitField(16, 6) + single[6:])); err == nil {
+		t.Errorf("DecodeInterrogation(payload string): expected error for wrong type")
+	}
+}
+
+func TestInterrogationRequestedTypes(t *testing.T) {
+	cases := []struct {
+		message   Interrogation
+		types     []uint8
+		longRange bool
+	}{
+		{Interrogation{Requests: []InterrogationRequest{{247123000, 5, 0}, {247123000, 24, 110}}}, []uint8{5, 24}, false},
+		{Interrogation{Requests: []InterrogationRequest{{247123000, 5, 0}, {247456000, 27, 0}}}, []uint8{5, 27}, true},
+		{Interrogation{}, []uint8{}, false},
+	}
+	for _, c := range cases {
+		types, longRange := c.message.RequestedTypes(), c.message.LongRange()
+		if !reflect.DeepEqual(types, c.types) || longRange != c.longRange {
+			fmt.Println("Got : ", types, longRange)
+			fmt.Println("Want: ", c.types, c.longRange)
+			t.Errorf("(Interrogation) RequestedTypes(), (Interrogation) LongRange()")
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"bytes"
+	"strings"
+)
+
+// utf8BOM is the byte order mark some editors and loggers write at the start of text files.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// NormalizeLines splits the contents of a log file into sentences ready to be passed to Router.
+// It accepts both LF and CRLF line endings, drops empty lines and removes a leading UTF-8 byte
+// order mark. It doesn't validate the sentences.
+func NormalizeLines(data []byte) []string {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if len(line) == 0 {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// IsHeartbeat reports whether a line is a keep-alive or comment line that some feeds send to keep
+// a connection open, rather than a sentence. Recognized are lines starting with # or // (comments)
+// and lines of only whitespace or NUL bytes. Router and ParseEnvelope return ErrHeartbeat for
+// them instead of a checksum failure. Empty lines are not heartbeats, they are ErrEmpty.
+func IsHeartbeat(line string) bool {
+	if len(line) == 0 {
+		return false
+	}
+	if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+		return true
+	}
+	return len(strings.Trim(line, " \t\r\x00")) == 0
+}
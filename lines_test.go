@@ -0,0 +1,82 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeLines(t *testing.T) {
+	sentences := []string{
+		"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F",
+		"!AIVDM,1,1,,A,14eGrSPP00ncMJTO5C6aBwvP2D0?,0*7A",
+	}
+	cases := []struct {
+		data string
+		want []string
+	}{
+		{sentences[0] + "\n" + sentences[1] + "\n", sentences},
+		{sentences[0] + "\r\n" + sentences[1] + "\r\n", sentences},
+		{sentences[0] + "\r\n\n" + sentences[1], sentences},
+		{"\xEF\xBB\xBF" + sentences[0] + "\r\n\r\n" + sentences[1] + "\n\n", sentences},
+		{"\r\n\n", nil},
+		{"", nil},
+	}
+	for _, c := range cases {
+		got := NormalizeLines([]byte(c.data))
+		if !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("NormalizeLines(data []byte)")
+		}
+	}
+}
+
+func TestIsHeartbeat(t *testing.T) {
+	cases := []struct {
+		line string
+		want bool
+	}{
+		{"# keep-alive", true},
+		{"#", true},
+		{"// comment", true},
+		{"   ", true},
+		{"\t\x00", true},
+		{"", false},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", false},
+		{" !AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", false},
+	}
+	for _, c := range cases {
+		if got := IsHeartbeat(c.line); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("IsHeartbeat(line string) for %q", c.line)
+		}
+		if !c.want {
+			continue
+		}
+		if _, err := Router(c.line); err != ErrHeartbeat {
+			t.Errorf("Router(sentence string, options ...RouterOption) for %q: got %v, want ErrHeartbeat", c.line, err)
+		}
+		if _, err := ParseEnvelope(c.line); err != ErrHeartbeat {
+			t.Errorf("ParseEnvelope(sentence string, options ...RouterOption) for %q: got %v, want ErrHeartbeat", c.line, err)
+		}
+	}
+}
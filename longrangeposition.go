@@ -0,0 +1,72 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+)
+
+// A LongRangePositionReport is a decoded AIS long range position report (message type 27).
+// It is a short (96 bits) position report meant for reception by satellites, with coarse
+// coordinates (1/10 min), speed and course. Since satellites may receive it long after it was
+// transmitted and the transponder may not have a fresh fix, check PositionLatency before
+// correlating it with terrestrial reports.
+// Please have a look at http://catb.org/gpsd/AIVDM.html
+type LongRangePositionReport struct {
+	Repeat          uint8
+	MMSI            uint32
+	Accuracy        bool    // position accuracy, true is high accuracy (<10m)
+	RAIM            bool    // RAIM flag, true if RAIM is in use
+	Status          uint8   // navigation status (enumerated type)
+	Lon             float64 // 181 means not available
+	Lat             float64 // 91 means not available
+	Speed           uint8   // knots, 63 means not available
+	Course          uint16  // degrees, 511 means not available
+	PositionLatency bool    // false if the position is the current GNSS position, true if it is older than 5 seconds
+}
+
+// DecodeLongRangePositionReport decodes the payload of a Type 27 AIS message
+func DecodeLongRangePositionReport(payload string) (LongRangePositionReport, error) {
+	data := []byte(payload)
+	var m LongRangePositionReport
+
+	mType := decodeAisChar(data[0])
+	if mType != 27 {
+		return m, errors.New("Message isn't Long Range Position Report (type 27).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+	m.Accuracy = cbnBool(38, data)
+	m.RAIM = cbnBool(39, data)
+	m.Status = uint8(bitsToInt(40, 43, data))
+
+	m.Lon, _ = decodeLongitude(int64(bitsToSignedInt(44, 61, data)), MinuteTenths)
+	m.Lat, _ = decodeLatitude(int64(bitsToSignedInt(62, 78, data)), MinuteTenths)
+
+	m.Speed = uint8(bitsToInt(79, 84, data))
+	m.Course = uint16(bitsToInt(85, 93, data))
+	m.PositionLatency = cbnBool(94, data)
+
+	return m, nil
+}
+
+// Position returns the coordinates of the report, it implements Positioned.
+func (m LongRangePositionReport) Position() (lat, lon float64, ok bool) {
+	return m.Lat, m.Lon, positionAvailable(m.Lon, m.Lat)
+}
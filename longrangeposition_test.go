@@ -0,0 +1,54 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeLongRangePositionReport(t *testing.T) {
+	cases := []struct {
+		payload string
+		want    LongRangePositionReport
+	}{
+		{
+			"KC5E2b@U19PFdLbL",
+			LongRangePositionReport{
+				Repeat: 1, MMSI: 206914217, Accuracy: false, RAIM: false, Status: 2, Lon: 137.02333333333334,
+				Lat: 4.84, Speed: 57, Course: 167, PositionLatency: false,
+			},
+		},
+		{
+			// Same report, with accuracy, RAIM and position latency set
+			armor(payloadBits("KC5E2b@U19PFdLbL")[:38] + "11" + payloadBits("KC5E2b@U19PFdLbL")[40:94] + "10"),
+			LongRangePositionReport{
+				Repeat: 1, MMSI: 206914217, Accuracy: true, RAIM: true, Status: 2, Lon: 137.02333333333334,
+				Lat: 4.84, Speed: 57, Course: 167, PositionLatency: true,
+			},
+		},
+	}
+	for _, c := range cases {
+		got, _ := DecodeLongRangePositionReport(c.payload)
+		if got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeLongRangePositionReport(payload string)")
+		}
+	}
+}
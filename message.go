@@ -0,0 +1,226 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"sync"
+	"time"
+)
+
+// BitLength returns the number of bits the payload of the message carries,
+// which is six bits per payload character minus the padding (fill) bits.
+func (m *Message) BitLength() int {
+	return len(m.Payload)*6 - int(m.Padding)
+}
+
+// FillBits returns the number of fill bits needed to pad a payload of bitLength bits to a
+// whole number of six bit characters. It is the value of the fill bits field of a sentence
+// carrying the payload (or its last fragment).
+func FillBits(bitLength int) int {
+	return (6 - bitLength%6) % 6
+}
+
+// Bit returns the bit at position i of the payload (bit 0 is the first bit of the message type).
+// Bits out of range (negative or past BitLength) are returned as false.
+func (m *Message) Bit(i int) bool {
+	if i < 0 || i >= m.BitLength() {
+		return false
+	}
+	return decodeAisChar(m.Payload[i/6])>>uint(5-i%6)&1 == 1
+}
+
+// Equal reports whether two messages are the same AIS message: same type, payload and padding.
+// It only considers the message itself, so transport metadata (e.g the radio channel or the
+// envelope of the sentences that carried it) is intentionally ignored. Two nil messages are equal.
+func (m *Message) Equal(other *Message) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	return m.Type == other.Type && m.Payload == other.Payload && m.Padding == other.Padding
+}
+
+// FNV-1a 64 bit parameters
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hash returns a 64 bit FNV-1a hash of the message type, payload and padding, so equal messages
+// (see Equal) have the same hash. It is stable across runs and platforms, so it may be persisted
+// e.g in deduplication state or golden files. It is not a cryptographic hash and should not be
+// used as a security primitive. It doesn't allocate. A nil message hashes to the FNV offset basis.
+func (m *Message) Hash() uint64 {
+	h := uint64(fnvOffset64)
+	if m == nil {
+		return h
+	}
+	h = (h ^ uint64(m.Type)) * fnvPrime64
+	for i := 0; i < len(m.Payload); i++ {
+		h = (h ^ uint64(m.Payload[i])) * fnvPrime64
+	}
+	return (h ^ uint64(m.Padding)) * fnvPrime64
+}
+
+// expectedBits holds the minimum and maximum length in bits of each message type, per ITU-R M.1371-5.
+// Variable length messages (e.g binary and safety messages) may take up to five slots.
+var expectedBits = map[uint8][2]int{
+	1: {168, 168}, 2: {168, 168}, 3: {168, 168}, 4: {168, 168}, 5: {424, 424},
+	6: {88, 1008}, 7: {72, 168}, 8: {56, 1008}, 9: {168, 168}, 10: {72, 72},
+	11: {168, 168}, 12: {72, 1008}, 13: {72, 168}, 14: {40, 1008}, 15: {88, 160},
+	16: {96, 144}, 17: {80, 816}, 18: {168, 168}, 19: {312, 312}, 20: {72, 160},
+	21: {272, 360}, 22: {168, 168}, 23: {160, 160}, 24: {160, 168}, 25: {40, 168},
+	26: {60, 1064}, 27: {96, 96},
+}
+
+// ExpectedBits returns the minimum and maximum length in bits a message of type mType may have.
+// For fixed length types min equals max. It returns false for unknown types.
+// Compare against BitLength to find truncated or overlong payloads before decoding them.
+// Please note that some transponders send type 5 messages two bits short (420 bits).
+func ExpectedBits(mType uint8) (min, max int, ok bool) {
+	bits, ok := expectedBits[mType]
+	return bits[0], bits[1], ok
+}
+
+// addressedTypes are the message types that can be sent to a destination MMSI. The first value
+// tells if they can be addressed, the second if they can be broadcast as well.
+var addressedTypes = map[uint8][2]bool{
+	6: {true, false}, 7: {true, false}, 10: {true, false}, 12: {true, false}, 13: {true, false},
+	15: {true, false}, 16: {true, false}, 22: {true, true}, 25: {true, true}, 26: {true, true},
+}
+
+// Addressing returns whether a message of type mType can be addressed to a station and whether it
+// can be broadcast. Some types (22, 25 and 26) can be either, a flag of the message tells which.
+// Both are false for unknown types.
+func Addressing(mType uint8) (addressed, broadcast bool) {
+	if _, ok := expectedBits[mType]; !ok {
+		return false, false
+	}
+	if a, ok := addressedTypes[mType]; ok {
+		return a[0], a[1]
+	}
+	return false, true
+}
+
+// RepeatIndicator returns the repeat indicator of a message (bits 6-7) without decoding the rest
+// of it: how many times a repeater has relayed the message, 3 means it shouldn't be repeated
+// further. The decoded messages carry it as their Repeat field. Like MMSI it doesn't allocate,
+// and it returns false for unknown types or payloads too short to carry it.
+func RepeatIndicator(m *Message) (uint8, bool) {
+	if m == nil || len(m.Payload) < 2 {
+		return 0, false
+	}
+	if _, ok := expectedBits[m.Type]; !ok {
+		return 0, false
+	}
+	return decodeAisChar(m.Payload[1]) >> 4, true
+}
+
+// FilterRepeats forwards the messages of in whose repeat indicator is at most max, so with
+// max 0 only the original transmissions pass and the copies relayed by repeaters are dropped.
+// Messages RepeatIndicator can't tell the indicator of pass through. The returned channel is
+// closed when in is closed.
+func FilterRepeats(in <-chan *Message, max uint8) <-chan *Message {
+	out := make(chan *Message)
+	go func() {
+		defer close(out)
+		for m := range in {
+			if repeat, ok := RepeatIndicator(m); ok && repeat > max {
+				continue
+			}
+			out <- m
+		}
+	}()
+	return out
+}
+
+// DefaultMergeWindow is a window for Merge that fits receivers sharing a network, a couple
+// of seconds of latency apart.
+const DefaultMergeWindow = 2 * time.Second
+
+// Merge fans in the messages of several streams, e.g from receivers with overlapping coverage,
+// into one. Each stream keeps its order. A message equal to one forwarded within window is
+// a copy of the same transmission and is dropped, a window of 0 keeps every copy. Messages of
+// type 255 (e.g an end of stream marker) and nil messages pass through. The returned channel
+// is closed when all the streams are closed, a stream closing early only stops its own goroutine.
+func Merge(window time.Duration, streams ...<-chan *Message) <-chan *Message {
+	merged := make(chan *Message)
+	var wg sync.WaitGroup
+	wg.Add(len(streams))
+	for _, in := range streams {
+		go func(in <-chan *Message) {
+			defer wg.Done()
+			for m := range in {
+				merged <- m
+			}
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+
+	out := make(chan *Message)
+	go func() {
+		defer close(out)
+		type entry struct {
+			hash uint64
+			at   time.Time
+		}
+		seen := make(map[uint64]time.Time)
+		var recent []entry // In arrival order, to forget the old ones
+		for m := range merged {
+			if m != nil && m.Type != 255 && window > 0 {
+				now := time.Now()
+				for len(recent) > 0 && now.Sub(recent[0].at) >= window {
+					if seen[recent[0].hash] == recent[0].at {
+						delete(seen, recent[0].hash)
+					}
+					recent = recent[1:]
+				}
+				h := m.Hash()
+				if _, ok := seen[h]; ok {
+					continue
+				}
+				seen[h] = now
+				recent = append(recent, entry{h, now})
+			}
+			out <- m
+		}
+	}()
+	return out
+}
+
+// MMSI returns the MMSI (source station) of a message without decoding the rest of it. It is
+// meant for the hot path of filters, so it doesn't allocate. All known message types carry the
+// MMSI at bits 8-37. It returns false for unknown types (e.g 255) or payloads too short to carry it.
+func MMSI(m *Message) (uint32, bool) {
+	if m == nil || len(m.Payload) < 7 {
+		return 0, false
+	}
+	if _, ok := expectedBits[m.Type]; !ok {
+		return 0, false
+	}
+
+	// Bits 8-37 are the last 4 bits of the 2nd character, the next four characters and
+	// the first 2 bits of the 7th character.
+	mmsi := uint32(decodeAisChar(m.Payload[1]) & 0x0F)
+	for i := 2; i < 6; i++ {
+		mmsi = mmsi<<6 | uint32(decodeAisChar(m.Payload[i]))
+	}
+	return mmsi<<2 | uint32(decodeAisChar(m.Payload[6])>>4), true
+}
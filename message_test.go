@@ -0,0 +1,340 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"testing"
+)
+
+func TestMessageBitLength(t *testing.T) {
+	cases := []struct {
+		message Message
+		want    int
+	}{
+		{Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}, 168},
+		{Message{5, "533iFNT00003W;3G;384iT<T400000000000001?88?73v0ik0RC1H11H30H51CU0E2CkP0", 2}, 424},
+		{Message{0, "", 0}, 0},
+	}
+	for _, c := range cases {
+		got := c.message.BitLength()
+		if got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("(*Message) BitLength()")
+		}
+	}
+}
+
+func TestFillBits(t *testing.T) {
+	cases := []struct {
+		bitLength int
+		want      int
+	}{
+		{0, 0}, {1, 5}, {5, 1}, {6, 0}, {7, 5}, {168, 0}, {424, 2}, {72, 0}, {96, 0}, {100, 2},
+	}
+	for _, c := range cases {
+		if got := FillBits(c.bitLength); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("FillBits(bitLength int) for %d bits", c.bitLength)
+		}
+		// Padding with the fill bits always gives whole characters
+		if (c.bitLength+c.want)%6 != 0 {
+			t.Errorf("FillBits(bitLength int) for %d bits doesn't pad to a whole character", c.bitLength)
+		}
+	}
+}
+
+func TestMessageBit(t *testing.T) {
+	// Type 3 is 000011, the bits that follow start with 001000
+	m := Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}
+	want := []bool{false, false, false, false, true, true, false, false, true, false, false, false}
+	for i, w := range want {
+		if got := m.Bit(i); got != w {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", w)
+			t.Errorf("(*Message) Bit(i int) for bit %d", i)
+		}
+	}
+
+	// The last bits of the payload are padding, they should not be read
+	m = Message{0, "0w", 2}
+	for _, i := range []int{-1, 10, 11, 12, 100} {
+		if m.Bit(i) {
+			t.Errorf("(*Message) Bit(i int) for out of range bit %d", i)
+		}
+	}
+	if !m.Bit(9) {
+		t.Errorf("(*Message) Bit(i int) for last bit")
+	}
+}
+
+func TestMessageEqual(t *testing.T) {
+	a, _ := Router("!AIVDM,1,1,,A,14eGrSPP00ncMJTO5C6aBwvP2D0?,0*7A")
+	b, _ := Router("!AIVDM,1,1,,B,14eGrSPP00ncMJTO5C6aBwvP2D0?,0*79") // Same message, other channel
+	cases := []struct {
+		m, other *Message
+		want     bool
+	}{
+		{a, b, true},
+		{a, &Message{1, "14eGrSPP00ncMJTO5C6aBwvP2D0?", 2}, false},
+		{a, &Message{3, "14eGrSPP00ncMJTO5C6aBwvP2D0?", 0}, false},
+		{a, &Message{1, "14eGrSPP00ncMJTO5C6aBwvP2D0@", 0}, false},
+		{a, nil, false},
+		{nil, nil, true},
+	}
+	for _, c := range cases {
+		if got := c.m.Equal(c.other); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("(*Message) Equal(other *Message)")
+		}
+	}
+}
+
+func TestExpectedBits(t *testing.T) {
+	messages := []Message{
+		{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0},
+		{5, "533iFNT00003W;3G;384iT<T400000000000001?88?73v0ik0RC1H11H30H51CU0E2CkP0", 2},
+		{8, "85Mwom1KfI?GR<NgcvM1Hg<P2FaGjRN<S22j;WN:IDle3f5Qsq6=620c;<gvsa8P?;j>Nl0oKaCLIdeFlr<Gh@Jc95:i>c0", 2},
+		{11, ";4R33:1uUK2F`q?mOt@@GoQ00000", 0},
+		{24, "H42O55i18tMET00000000000000", 2},
+		{27, "KC5E2b@U19PFdLbL", 0},
+	}
+	for _, m := range messages {
+		min, max, ok := ExpectedBits(m.Type)
+		if !ok || m.BitLength() < min || m.BitLength() > max {
+			fmt.Println("Got : ", min, max, ok)
+			fmt.Println("Want: ", m.BitLength())
+			t.Errorf("ExpectedBits(mType uint8) for type %d", m.Type)
+		}
+	}
+
+	if _, _, ok := ExpectedBits(28); ok {
+		t.Errorf("ExpectedBits(mType uint8) for unknown type")
+	}
+}
+
+func TestAddressing(t *testing.T) {
+	cases := []struct {
+		mType                uint8
+		addressed, broadcast bool
+	}{
+		{1, false, true},
+		{6, true, false},
+		{8, false, true},
+		{12, true, false},
+		{14, false, true},
+		{22, true, true},
+		{0, false, false},
+		{28, false, false},
+	}
+	for _, c := range cases {
+		addressed, broadcast := Addressing(c.mType)
+		if addressed != c.addressed || broadcast != c.broadcast {
+			fmt.Println("Got : ", addressed, broadcast)
+			fmt.Println("Want: ", c.addressed, c.broadcast)
+			t.Errorf("Addressing(mType uint8) for type %d", c.mType)
+		}
+	}
+}
+
+func TestMMSI(t *testing.T) {
+	cases := []struct {
+		message *Message
+		mmsi    uint32
+		ok      bool
+	}{
+		{&Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}, 601041200, true},
+		{&Message{4, "402R3KiutR0Qk156V4QQTOA00<0;", 0}, 2655087, true},
+		{&Message{24, "H42O55i18tMET00000000000000", 2}, 271041815, true},
+		{&Message{27, "KC5E2b@U19PFdLbL", 0}, 206914217, true},
+		{&Message{255, "", 0}, 0, false},
+		{&Message{1, "14eG", 0}, 0, false},
+		{nil, 0, false},
+	}
+	for _, c := range cases {
+		mmsi, ok := MMSI(c.message)
+		if mmsi != c.mmsi || ok != c.ok {
+			fmt.Println("Got : ", mmsi, ok)
+			fmt.Println("Want: ", c.mmsi, c.ok)
+			t.Errorf("MMSI(m *Message)")
+		}
+	}
+}
+
+func TestMessageHash(t *testing.T) {
+	messages := []*Message{
+		{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0},
+		{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 2},
+		{1, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0},
+		{5, "", 0},
+	}
+	seen := make(map[uint64]bool)
+	for _, m := range messages {
+		// Must match the standard library FNV-1a over type, payload and padding
+		h := fnv.New64a()
+		h.Write(append(append([]byte{m.Type}, m.Payload...), m.Padding))
+		want := h.Sum64()
+		if got := m.Hash(); got != want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", want)
+			t.Errorf("(*Message) Hash() for %v", *m)
+		}
+		if seen[m.Hash()] {
+			t.Errorf("(*Message) Hash() collision for %v", *m)
+		}
+		seen[m.Hash()] = true
+	}
+
+	copied := *messages[0]
+	if copied.Hash() != messages[0].Hash() {
+		t.Errorf("(*Message) Hash() differs for equal messages")
+	}
+	var nilMessage *Message
+	if nilMessage.Hash() != fnv.New64a().Sum64() {
+		t.Errorf("(*Message) Hash() for a nil message")
+	}
+}
+
+func TestRepeatIndicator(t *testing.T) {
+	relayed := armor(bitField(1, 6) + bitField(2, 2) + bitField(601041200, 30))
+	cases := []struct {
+		message *Message
+		repeat  uint8
+		ok      bool
+	}{
+		{&Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}, 0, true},
+		{&Message{27, "KC5E2b@U19PFdLbL", 0}, 1, true},
+		{&Message{1, relayed, 0}, 2, true},
+		{&Message{1, "1", 0}, 0, false},
+		{&Message{255, "", 0}, 0, false},
+		{nil, 0, false},
+	}
+	for _, c := range cases {
+		repeat, ok := RepeatIndicator(c.message)
+		if repeat != c.repeat || ok != c.ok {
+			fmt.Println("Got : ", repeat, ok)
+			fmt.Println("Want: ", c.repeat, c.ok)
+			t.Errorf("RepeatIndicator(m *Message)")
+		}
+	}
+}
+
+func TestFilterRepeats(t *testing.T) {
+	original := &Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}
+	relayed := &Message{1, armor(bitField(1, 6) + bitField(1, 2) + bitField(601041200, 30)), 0}
+	relayedTwice := &Message{1, armor(bitField(1, 6) + bitField(2, 2) + bitField(601041200, 30)), 0}
+	unknown := &Message{255, "", 0}
+	messages := []*Message{original, relayed, relayedTwice, unknown}
+
+	cases := []struct {
+		max  uint8
+		want []*Message
+	}{
+		{0, []*Message{original, unknown}},
+		{1, []*Message{original, relayed, unknown}},
+		{3, messages},
+	}
+	for _, c := range cases {
+		in := make(chan *Message)
+		go func() {
+			for _, m := range messages {
+				in <- m
+			}
+			close(in)
+		}()
+		var got []*Message
+		for m := range FilterRepeats(in, c.max) {
+			got = append(got, m)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("FilterRepeats(in <-chan *Message, max uint8) for max %d", c.max)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := &Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}
+	b := &Message{4, "402R3KiutR0Qk156V4QQTOA00<0;", 0}
+	c := &Message{20, "Dh3OvjB8IN>4", 0}
+	marker := &Message{255, "", 0}
+
+	send := func(messages ...*Message) <-chan *Message {
+		in := make(chan *Message)
+		go func() {
+			for _, m := range messages {
+				in <- m
+			}
+			close(in)
+		}()
+		return in
+	}
+	closed := make(chan *Message)
+	close(closed)
+
+	// Both receivers got a and a marker, the first closes before the second is done
+	got := make(map[uint64]int)
+	for m := range Merge(DefaultMergeWindow, send(a, marker), send(marker, &Message{3, a.Payload, 0}, b, c), closed) {
+		got[m.Hash()]++
+	}
+	want := map[uint64]int{a.Hash(): 1, b.Hash(): 1, c.Hash(): 1, marker.Hash(): 2}
+	if !reflect.DeepEqual(got, want) {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("Merge(window time.Duration, streams ...<-chan *Message)")
+	}
+
+	// A window of 0 keeps every copy
+	got = make(map[uint64]int)
+	for m := range Merge(0, send(a), send(&Message{3, a.Payload, 0}, b)) {
+		got[m.Hash()]++
+	}
+	want = map[uint64]int{a.Hash(): 2, b.Hash(): 1}
+	if !reflect.DeepEqual(got, want) {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("Merge(window time.Duration, streams ...<-chan *Message) with a window of 0")
+	}
+
+	if _, ok := <-Merge(DefaultMergeWindow); ok {
+		t.Errorf("Merge(window time.Duration, streams ...<-chan *Message) without streams should be closed")
+	}
+}
+
+// MMSI is meant for the hot path, it should report 0 allocs/op.
+func BenchmarkMMSI(b *testing.B) {
+	m := &Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		MMSI(m)
+	}
+}
+
+func BenchmarkMessageHash(b *testing.B) {
+	m := &Message{3, "38u<a<?PAA2>P:WfuAO9PW<P0PuQ", 0}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Hash()
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+	"math"
+)
+
+// MetHydro is a decoded Meteorological and Hydrographic Data message (DAC 1, FI 31, or FI 11
+// for the legacy format). Values are converted to their units, so the legacy and current formats
+// decode to the same structure. Measurements the station doesn't report are NaN, enumerations
+// and directions keep their not available values. The unit conversion methods report NaN
+// measurements as not available.
+//
+// The legacy format (IMO SN/Circ.236) was superseded by IMO SN.1/Circ.289 in 2013, but many
+// stations installed before that were never upgraded and still transmit it under FI 11.
+// The two formats place and scale their fields differently, so decoding one as the other gives
+// wrong values.
+type MetHydro struct {
+	Legacy            bool    // Decoded from the legacy (FI 11) format
+	Lon               float64 // (sc 1/1000 min)
+	Lat               float64 // (sc 1/1000 min)
+	Accuracy          bool    // Position accuracy, not in legacy format
+	Day               uint8   // UTC, 0 means not available
+	Hour              uint8   // UTC, 24 means not available
+	Minute            uint8   // UTC, 60 means not available
+	WindSpeed         float32 // Average of last 10 minutes, knots
+	WindGust          float32 // Maximum of last 10 minutes, knots
+	WindDirection     uint16  // Degrees, 360 means not available
+	WindGustDirection uint16  // Degrees, 360 means not available
+	AirTemperature    float32 // °C
+	Humidity          float32 // Relative, %
+	DewPoint          float32 // °C
+	Pressure          float32 // hPa
+	PressureTendency  uint8   // 0 steady, 1 decreasing, 2 increasing, 3 not available
+	Visibility        float32 // Nautical miles
+	VisibilityGreater bool    // Visibility is greater than the value reported, not in legacy format
+	WaterLevel        float32 // Deviation from local chart datum, meters
+	WaterLevelTrend   uint8   // 0 steady, 1 decreasing, 2 increasing, 3 not available
+	Currents          [3]MetHydroCurrent
+	WaveHeight        float32 // Significant wave height, meters
+	WavePeriod        float32 // Seconds
+	WaveDirection     uint16  // Degrees, 360 means not available
+	SwellHeight       float32 // Meters
+	SwellPeriod       float32 // Seconds
+	SwellDirection    uint16  // Degrees, 360 means not available
+	SeaState          uint8   // Beaufort scale, 13 or more means not available
+	WaterTemperature  float32 // °C
+	Precipitation     uint8   // Precipitation type (enumeration at PrecipitationTypes)
+	Salinity          float32 // ‰
+	Ice               uint8   // 0 no, 1 yes, 3 not available
+}
+
+// A MetHydroCurrent is a water current measurement of a MetHydro message. The first one is
+// the surface current, so its depth is always 0.
+type MetHydroCurrent struct {
+	Speed     float32 // Knots
+	Direction uint16  // Degrees, 360 means not available
+	Depth     float32 // Meters
+}
+
+// Precipitation types
+var PrecipitationTypes = [...]string{
+	"reserved", "rain", "thunderstorm", "freezing rain", "mixed/ice", "snow", "reserved", "not available",
+}
+
+// metConvert converts a decoded measurement to another unit. It returns false for measurements
+// that aren't available, so that they aren't plotted or averaged as if they were real values.
+func metConvert(v, mul, offset float32) (float32, bool) {
+	if math.IsNaN(float64(v)) {
+		return 0, false
+	}
+	return v*mul + offset, true
+}
+
+// WindSpeedKnots returns the average wind speed in knots and whether it is available.
+func (m MetHydro) WindSpeedKnots() (float32, bool) { return metConvert(m.WindSpeed, 1, 0) }
+
+// WindSpeedMetersPerSecond returns the average wind speed in m/s and whether it is available.
+func (m MetHydro) WindSpeedMetersPerSecond() (float32, bool) {
+	return metConvert(m.WindSpeed, nauticalMile/3600, 0)
+}
+
+// WindGustKnots returns the wind gust speed in knots and whether it is available.
+func (m MetHydro) WindGustKnots() (float32, bool) { return metConvert(m.WindGust, 1, 0) }
+
+// WindGustMetersPerSecond returns the wind gust speed in m/s and whether it is available.
+func (m MetHydro) WindGustMetersPerSecond() (float32, bool) {
+	return metConvert(m.WindGust, nauticalMile/3600, 0)
+}
+
+// AirTemperatureCelsius returns the air temperature in °C and whether it is available.
+func (m MetHydro) AirTemperatureCelsius() (float32, bool) { return metConvert(m.AirTemperature, 1, 0) }
+
+// AirTemperatureFahrenheit returns the air temperature in °F and whether it is available.
+func (m MetHydro) AirTemperatureFahrenheit() (float32, bool) {
+	return metConvert(m.AirTemperature, 1.8, 32)
+}
+
+// DewPointCelsius returns the dew point in °C and whether it is available.
+func (m MetHydro) DewPointCelsius() (float32, bool) { return metConvert(m.DewPoint, 1, 0) }
+
+// DewPointFahrenheit returns the dew point in °F and whether it is available.
+func (m MetHydro) DewPointFahrenheit() (float32, bool) { return metConvert(m.DewPoint, 1.8, 32) }
+
+// WaterTemperatureCelsius returns the water temperature in °C and whether it is available.
+func (m MetHydro) WaterTemperatureCelsius() (float32, bool) {
+	return metConvert(m.WaterTemperature, 1, 0)
+}
+
+// WaterTemperatureFahrenheit returns the water temperature in °F and whether it is available.
+func (m MetHydro) WaterTemperatureFahrenheit() (float32, bool) {
+	return metConvert(m.WaterTemperature, 1.8, 32)
+}
+
+// PressureHectopascals returns the air pressure in hPa and whether it is available.
+func (m MetHydro) PressureHectopascals() (float32, bool) { return metConvert(m.Pressure, 1, 0) }
+
+// WaveHeightMeters returns the significant wave height in meters and whether it is available.
+func (m MetHydro) WaveHeightMeters() (float32, bool) { return metConvert(m.WaveHeight, 1, 0) }
+
+// metValue scales a raw measurement to its unit, or returns NaN if it isn't available.
+func metValue(raw int32, available bool, div, offset float32) float32 {
+	if !available {
+		return float32(math.NaN())
+	}
+	return float32(raw)/div + offset
+}
+
+// DecodeMetHydro decodes the application specific data of a Meteorological and Hydrographic
+// Data message (DAC 1, FI 31) as specified in IMO SN.1/Circ.289.
+func DecodeMetHydro(data []byte) (MetHydro, error) {
+	var m MetHydro
+
+	if len(data)*6 < 304 {
+		return m, errors.New("Meteorological and Hydrographic Data message is too short.")
+	}
+
+	m.Lon, _ = decodeLongitude(int64(bitsToSignedInt(0, 24, data)), MinuteThousandths)
+	m.Lat, _ = decodeLatitude(int64(bitsToSignedInt(25, 48, data)), MinuteThousandths)
+	m.Accuracy = cbnBool(49, data)
+
+	m.Day = uint8(bitsToInt(50, 54, data))
+	m.Hour = uint8(bitsToInt(55, 59, data))
+	m.Minute = uint8(bitsToInt(60, 65, data))
+
+	raw := int32(bitsToInt(66, 72, data))
+	m.WindSpeed = metValue(raw, raw != 127, 1, 0)
+	raw = int32(bitsToInt(73, 79, data))
+	m.WindGust = metValue(raw, raw != 127, 1, 0)
+	m.WindDirection = uint16(bitsToInt(80, 88, data))
+	m.WindGustDirection = uint16(bitsToInt(89, 97, data))
+
+	raw = bitsToSignedInt(98, 108, data)
+	m.AirTemperature = metValue(raw, raw != -1024, 10, 0)
+	raw = int32(bitsToInt(109, 115, data))
+	m.Humidity = metValue(raw, raw <= 100, 1, 0)
+	raw = bitsToSignedInt(116, 125, data)
+	m.DewPoint = metValue(raw, raw <= 500, 10, 0)
+	raw = int32(bitsToInt(126, 134, data))
+	m.Pressure = metValue(raw, raw <= 402, 1, 799)
+	m.PressureTendency = uint8(bitsToInt(135, 136, data))
+
+	m.VisibilityGreater = cbnBool(137, data)
+	raw = int32(bitsToInt(138, 144, data))
+	m.Visibility = metValue(raw, raw != 127, 10, 0)
+
+	raw = int32(bitsToInt(145, 156, data))
+	m.WaterLevel = metValue(raw, raw <= 4000, 100, -10)
+	m.WaterLevelTrend = uint8(bitsToInt(157, 158, data))
+
+	m.Currents[0] = decodeMetHydroCurrent(159, -1, data)
+	m.Currents[1] = decodeMetHydroCurrent(176, 193, data)
+	m.Currents[2] = decodeMetHydroCurrent(198, 215, data)
+
+	decodeMetHydroSea(&m, 220, data)
+
+	raw = bitsToSignedInt(270, 279, data)
+	m.WaterTemperature = metValue(raw, raw <= 500, 10, 0)
+	m.Precipitation = uint8(bitsToInt(280, 282, data))
+	raw = int32(bitsToInt(283, 291, data))
+	m.Salinity = metValue(raw, raw <= 500, 10, 0)
+	m.Ice = uint8(bitsToInt(292, 293, data))
+
+	return m, nil
+}
+
+// DecodeMetHydroLegacy decodes the application specific data of a Meteorological and Hydrographic
+// Data message in the legacy format (DAC 1, FI 11) of IMO SN/Circ.236.
+func DecodeMetHydroLegacy(data []byte) (MetHydro, error) {
+	var m MetHydro
+	m.Legacy = true
+
+	if len(data)*6 < 296 {
+		return m, errors.New("Meteorological and Hydrographic Data message is too short.")
+	}
+
+	m.Lat, _ = decodeLatitude(int64(bitsToSignedInt(0, 23, data)), MinuteThousandths)
+	m.Lon, _ = decodeLongitude(int64(bitsToSignedInt(24, 48, data)), MinuteThousandths)
+
+	m.Day = uint8(bitsToInt(49, 53, data))
+	m.Hour = uint8(bitsToInt(54, 58, data))
+	m.Minute = uint8(bitsToInt(59, 64, data))
+
+	raw := int32(bitsToInt(65, 71, data))
+	m.WindSpeed = metValue(raw, raw != 127, 1, 0)
+	raw = int32(bitsToInt(72, 78, data))
+	m.WindGust = metValue(raw, raw != 127, 1, 0)
+	m.WindDirection = uint16(bitsToInt(79, 87, data))
+	m.WindGustDirection = uint16(bitsToInt(88, 96, data))
+
+	raw = int32(bitsToInt(97, 107, data))
+	m.AirTemperature = metValue(raw, raw != 2047, 10, -60)
+	raw = int32(bitsToInt(108, 114, data))
+	m.Humidity = metValue(raw, raw != 127, 1, 0)
+	raw = int32(bitsToInt(115, 124, data))
+	m.DewPoint = metValue(raw, raw != 1023, 10, -20)
+	raw = int32(bitsToInt(125, 133, data))
+	m.Pressure = metValue(raw, raw != 511, 1, 800)
+	m.PressureTendency = uint8(bitsToInt(134, 135, data))
+
+	raw = int32(bitsToInt(136, 143, data))
+	m.Visibility = metValue(raw, raw != 255, 10, 0)
+
+	raw = int32(bitsToInt(144, 152, data))
+	m.WaterLevel = metValue(raw, raw != 511, 10, -10)
+	m.WaterLevelTrend = uint8(bitsToInt(153, 154, data))
+
+	m.Currents[0] = decodeMetHydroCurrent(155, -1, data)
+	m.Currents[1] = decodeMetHydroCurrent(172, 189, data)
+	m.Currents[2] = decodeMetHydroCurrent(194, 211, data)
+
+	decodeMetHydroSea(&m, 216, data)
+
+	raw = int32(bitsToInt(266, 275, data))
+	m.WaterTemperature = metValue(raw, raw != 1023, 10, -10)
+	m.Precipitation = uint8(bitsToInt(276, 278, data))
+	raw = int32(bitsToInt(279, 287, data))
+	m.Salinity = metValue(raw, raw != 511, 10, 0)
+	m.Ice = uint8(bitsToInt(288, 289, data))
+
+	return m, nil
+}
+
+// decodeMetHydroFI11 decodes a DAC 1, FI 11 message. Some stations transmit the current format
+// under the legacy FI, we tell them apart from their length (296 vs 304 bits).
+func decodeMetHydroFI11(data []byte) (MetHydro, error) {
+	if len(data)*6 >= 304 {
+		return DecodeMetHydro(data)
+	}
+	return DecodeMetHydroLegacy(data)
+}
+
+// decodeMetHydroCurrent decodes the speed and direction of a current starting at bit first,
+// and its depth starting at bit depth (-1 for the surface current). It is the same in both formats.
+func decodeMetHydroCurrent(first, depth int, data []byte) MetHydroCurrent {
+	var c MetHydroCurrent
+
+	raw := int32(bitsToInt(first, first+7, data))
+	c.Speed = metValue(raw, raw != 255, 10, 0)
+	c.Direction = uint16(bitsToInt(first+8, first+16, data))
+	if depth >= 0 {
+		raw = int32(bitsToInt(depth, depth+4, data))
+		c.Depth = metValue(raw, raw != 31, 1, 0)
+	}
+
+	return c
+}
+
+// decodeMetHydroSea decodes the waves, swell and sea state block starting at bit first.
+// It is the same in both formats.
+func decodeMetHydroSea(m *MetHydro, first int, data []byte) {
+	raw := int32(bitsToInt(first, first+7, data))
+	m.WaveHeight = metValue(raw, raw != 255, 10, 0)
+	raw = int32(bitsToInt(first+8, first+13, data))
+	m.WavePeriod = metValue(raw, raw != 63, 1, 0)
+	m.WaveDirection = uint16(bitsToInt(first+14, first+22, data))
+
+	raw = int32(bitsToInt(first+23, first+30, data))
+	m.SwellHeight = metValue(raw, raw != 255, 10, 0)
+	raw = int32(bitsToInt(first+31, first+36, data))
+	m.SwellPeriod = metValue(raw, raw != 63, 1, 0)
+	m.SwellDirection = uint16(bitsToInt(first+37, first+45, data))
+
+	m.SeaState = uint8(bitsToInt(first+46, first+49, data))
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeMetHydro(t *testing.T) {
+	position := bitField(1410000, 25) + bitField(2235000, 24) + bitField(1, 1)
+	legacyPosition := bitField(2235000, 24) + bitField(1410000, 25)
+	wind := bitField(14, 5) + bitField(11, 5) + bitField(30, 6) +
+		bitField(12, 7) + bitField(18, 7) + bitField(270, 9) + bitField(280, 9)
+	// Currents, waves and swell are encoded the same in both formats
+	sea := bitField(15, 8) + bitField(90, 9) +
+		bitField(5, 8) + bitField(100, 9) + bitField(5, 5) +
+		bitField(5, 8) + bitField(110, 9) + bitField(10, 5) +
+		bitField(15, 8) + bitField(6, 6) + bitField(250, 9) +
+		bitField(10, 8) + bitField(8, 6) + bitField(240, 9) + bitField(4, 4)
+
+	current := position + wind + bitField(215, 11) + bitField(65, 7) + bitField(145, 10) +
+		bitField(214, 9) + bitField(1, 2) + bitField(0, 1) + bitField(85, 7) + bitField(1050, 12) +
+		bitField(2, 2) + sea + bitField(185, 10) + bitField(1, 3) + bitField(385, 9) + bitField(0, 2) +
+		bitField(0, 10)
+	legacy := legacyPosition + wind + bitField(815, 11) + bitField(65, 7) + bitField(345, 10) +
+		bitField(213, 9) + bitField(1, 2) + bitField(85, 8) + bitField(105, 9) +
+		bitField(2, 2) + sea + bitField(285, 10) + bitField(1, 3) + bitField(385, 9) + bitField(0, 2) +
+		bitField(0, 6)
+
+	want := MetHydro{
+		Lon: 23.5, Lat: 37.25, Accuracy: true, Day: 14, Hour: 11, Minute: 30,
+		WindSpeed: 12, WindGust: 18, WindDirection: 270, WindGustDirection: 280,
+		AirTemperature: 21.5, Humidity: 65, DewPoint: 14.5, Pressure: 1013, PressureTendency: 1,
+		Visibility: 8.5, WaterLevel: 0.5, WaterLevelTrend: 2,
+		Currents:   [3]MetHydroCurrent{{1.5, 90, 0}, {0.5, 100, 5}, {0.5, 110, 10}},
+		WaveHeight: 1.5, WavePeriod: 6, WaveDirection: 250, SwellHeight: 1, SwellPeriod: 8, SwellDirection: 240,
+		SeaState: 4, WaterTemperature: 18.5, Precipitation: 1, Salinity: 38.5, Ice: 0,
+	}
+	wantLegacy := want
+	wantLegacy.Legacy = true
+	wantLegacy.Accuracy = false
+
+	cases := []struct {
+		fi   uint8
+		bits string
+		want MetHydro
+	}{
+		{31, current, want},
+		{11, legacy, wantLegacy},
+		{11, current, want}, // Current format under the legacy FI
+	}
+	for _, c := range cases {
+		got, err := DecodeBinaryData(1, c.fi, []byte(armor(c.bits)))
+		if err != nil || !reflect.DeepEqual(got, c.want) {
+			fmt.Println("Got : ", got, err)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeBinaryData(dac uint16, fi uint8, data []byte) for FI %d", c.fi)
+		}
+	}
+}
+
+func TestDecodeMetHydroNotAvailable(t *testing.T) {
+	current := bitField(181*60000, 25) + bitField(91*60000, 24) + bitField(0, 1) +
+		bitField(0, 5) + bitField(24, 5) + bitField(60, 6) + bitField(127, 7) + bitField(127, 7) +
+		bitField(360, 9) + bitField(360, 9) + bitField(-1024, 11) + bitField(101, 7) + bitField(501, 10) +
+		bitField(511, 9) + bitField(3, 2) + bitField(0, 1) + bitField(127, 7) + bitField(4001, 12) +
+		bitField(3, 2) + bitField(255, 8) + bitField(360, 9) +
+		bitField(255, 8) + bitField(360, 9) + bitField(31, 5) + bitField(255, 8) + bitField(360, 9) + bitField(31, 5) +
+		bitField(255, 8) + bitField(63, 6) + bitField(360, 9) + bitField(255, 8) + bitField(63, 6) + bitField(360, 9) +
+		bitField(13, 4) + bitField(501, 10) + bitField(7, 3) + bitField(510, 9) + bitField(3, 2) + bitField(0, 10)
+
+	m, err := DecodeMetHydro([]byte(armor(current)))
+	if err != nil {
+		t.Fatalf("DecodeMetHydro(data []byte): %s", err)
+	}
+	values := []float32{m.WindSpeed, m.WindGust, m.AirTemperature, m.Humidity, m.DewPoint, m.Pressure,
+		m.Visibility, m.WaterLevel, m.Currents[0].Speed, m.Currents[1].Speed, m.Currents[1].Depth,
+		m.Currents[2].Speed, m.Currents[2].Depth, m.WaveHeight, m.WavePeriod, m.SwellHeight, m.SwellPeriod,
+		m.WaterTemperature, m.Salinity}
+	for i, v := range values {
+		if !math.IsNaN(float64(v)) {
+			t.Errorf("DecodeMetHydro(data []byte): value %d should be not available, got %f", i, v)
+		}
+	}
+	if m.Lon != 181 || m.Lat != 91 {
+		t.Errorf("DecodeMetHydro(data []byte): position should be not available")
+	}
+
+	if _, err := DecodeMetHydroLegacy([]byte(armor(current[:200]))); err == nil {
+		t.Errorf("DecodeMetHydroLegacy(data []byte): expected error for short message")
+	}
+}
+
+func TestMetHydroConversions(t *testing.T) {
+	nan := float32(math.NaN())
+	m := MetHydro{WindSpeed: 10, WindGust: 20, AirTemperature: 20, DewPoint: -10, WaterTemperature: 0,
+		Pressure: 1013, WaveHeight: 1.5}
+	na := MetHydro{WindSpeed: nan, WindGust: nan, AirTemperature: nan, DewPoint: nan, WaterTemperature: nan,
+		Pressure: nan, WaveHeight: nan}
+
+	conversions := []struct {
+		name string
+		f    func(MetHydro) (float32, bool)
+		want float32
+	}{
+		{"WindSpeedKnots", MetHydro.WindSpeedKnots, 10},
+		{"WindSpeedMetersPerSecond", MetHydro.WindSpeedMetersPerSecond, 5.144},
+		{"WindGustKnots", MetHydro.WindGustKnots, 20},
+		{"WindGustMetersPerSecond", MetHydro.WindGustMetersPerSecond, 10.289},
+		{"AirTemperatureCelsius", MetHydro.AirTemperatureCelsius, 20},
+		{"AirTemperatureFahrenheit", MetHydro.AirTemperatureFahrenheit, 68},
+		{"DewPointCelsius", MetHydro.DewPointCelsius, -10},
+		{"DewPointFahrenheit", MetHydro.DewPointFahrenheit, 14},
+		{"WaterTemperatureCelsius", MetHydro.WaterTemperatureCelsius, 0},
+		{"WaterTemperatureFahrenheit", MetHydro.WaterTemperatureFahrenheit, 32},
+		{"PressureHectopascals", MetHydro.PressureHectopascals, 1013},
+		{"WaveHeightMeters", MetHydro.WaveHeightMeters, 1.5},
+	}
+	for _, c := range conversions {
+		got, ok := c.f(m)
+		if !ok || math.Abs(float64(got-c.want)) > 0.001 {
+			fmt.Println("Got : ", got, ok)
+			fmt.Println("Want: ", c.want, true)
+			t.Errorf("MetHydro.%s()", c.name)
+		}
+		if got, ok := c.f(na); ok || got != 0 {
+			fmt.Println("Got : ", got, ok)
+			fmt.Println("Want: ", 0, false)
+			t.Errorf("MetHydro.%s() for not available value", c.name)
+		}
+	}
+}
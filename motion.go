@@ -0,0 +1,75 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+// A Motion tells if a vessel is underway or stationary (moored, anchored, etc.), as derived
+// from a position report by ClassifyMotion.
+type Motion uint8
+
+// Motion classifications
+const (
+	MotionUnknown Motion = iota
+	MotionMoving
+	MotionStationary
+)
+
+// DefaultStationarySpeed is a speed over ground (knots) under which a vessel can be considered
+// stationary. GPS jitter makes moored vessels report a small, non zero SOG.
+const DefaultStationarySpeed float32 = 0.2
+
+// String returns a description of the motion classification
+func (m Motion) String() string {
+	switch m {
+	case MotionMoving:
+		return "moving"
+	case MotionStationary:
+		return "stationary"
+	}
+	return "unknown"
+}
+
+// ClassifyMotion classifies a Class A position report as moving or stationary. A vessel is
+// stationary if its navigation status is at anchor, moored or aground, or if its SOG is
+// under stationarySpeed (knots, e.g DefaultStationarySpeed). If the status doesn't tell and
+// the SOG isn't available, the motion is unknown rather than stationary.
+func ClassifyMotion(r ClassAPositionReport, stationarySpeed float32) Motion {
+	switch r.Status {
+	case 1, 5, 6: // At anchor, moored, aground
+		return MotionStationary
+	}
+	switch {
+	case r.Speed == 1023:
+		return MotionUnknown
+	case r.Speed < stationarySpeed:
+		return MotionStationary
+	}
+	return MotionMoving
+}
+
+// FilterMotion returns the reports of a stream (or batch) that ClassifyMotion classifies as motion,
+// keeping their order. E.g FilterMotion(reports, MotionMoving, DefaultStationarySpeed) returns
+// the reports of vessels underway.
+func FilterMotion(reports []ClassAPositionReport, motion Motion, stationarySpeed float32) []ClassAPositionReport {
+	var filtered []ClassAPositionReport
+	for _, r := range reports {
+		if ClassifyMotion(r, stationarySpeed) == motion {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestClassifyMotion(t *testing.T) {
+	report := func(status uint8, speed float32) ClassAPositionReport {
+		var r ClassAPositionReport
+		r.Status, r.Speed = status, speed
+		return r
+	}
+	cases := []struct {
+		report ClassAPositionReport
+		want   Motion
+		name   string
+	}{
+		{report(0, 12.3), MotionMoving, "moving"},
+		{report(0, 0.1), MotionStationary, "stationary"},
+		{report(5, 0.4), MotionStationary, "moored"},
+		{report(1, 1023), MotionStationary, "at anchor, no SOG"},
+		{report(15, 1023), MotionUnknown, "unknown"},
+		{report(0, 1022), MotionMoving, "102.2 knots or more"},
+	}
+	var reports []ClassAPositionReport
+	for _, c := range cases {
+		reports = append(reports, c.report)
+		if got := ClassifyMotion(c.report, DefaultStationarySpeed); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("ClassifyMotion(r ClassAPositionReport, stationarySpeed float32) for %s", c.name)
+		}
+	}
+	if got := ClassifyMotion(report(0, 0.1), 0.05); got != MotionMoving {
+		t.Errorf("ClassifyMotion(r ClassAPositionReport, stationarySpeed float32) for a lower threshold, got %s", got)
+	}
+
+	if got := FilterMotion(reports, MotionStationary, DefaultStationarySpeed); len(got) != 3 || got[0] != reports[1] || got[2] != reports[3] {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", reports[1:4])
+		t.Errorf("FilterMotion(reports []ClassAPositionReport, motion Motion, stationarySpeed float32)")
+	}
+	if got := MotionUnknown.String(); got != "unknown" {
+		t.Errorf("(Motion) String()")
+	}
+}
@@ -17,10 +17,14 @@
 
 package aislib
 
-import "encoding/hex"
+import (
+	"encoding/hex"
+	"strings"
+)
 
 // Nmea183ChecksumCheck performs a checksum check for NMEA183 sentences.
-// AIS messages are NMEA183 encoded.
+// AIS messages are NMEA183 encoded. The hex digits of the checksum may be upper or lower case,
+// see StrictChecksumCase to accept only upper case.
 func Nmea183ChecksumCheck(sentence string) bool {
 	length := len(sentence)
 	if length < 5 { // Sentence isn't long enough to have a csum, avoid bounds out of range
@@ -34,16 +38,52 @@ func Nmea183ChecksumCheck(sentence string) bool {
 	}
 
 	// The checksum is calculated from the whole sentence except
-	// the first and last three characters
-	bline := []byte(sentence[1 : length-3])
-	ccsum := bline[0]
-	// The checksum is calculated by XOR'ing all the characters
-	for i := 1; i < len(bline); i++ {
-		ccsum ^= bline[i]
-	}
-
-	if csum[0] == ccsum {
+	// the delimiter (first character) and last three characters.
+	// Some sources omit the delimiter, then we start from the first character.
+	if csum[0] == ComputeChecksum(sentence[delimiterLength(sentence):length-3]) {
 		return true
 	}
 	return false
 }
+
+// ComputeChecksum returns the raw NMEA 0183 checksum byte of the body of a sentence, the
+// characters between the delimiter and the *. It is calculated by XOR'ing all the characters.
+func ComputeChecksum(body string) byte {
+	var ccsum byte
+	for i := 0; i < len(body); i++ {
+		ccsum ^= body[i]
+	}
+	return ccsum
+}
+
+// Nmea183ChecksumAppend appends the checksum field (*HH, upper case) to a sentence without one,
+// e.g to build test fixtures. A leading delimiter is kept out of the checksum.
+func Nmea183ChecksumAppend(sentence string) string {
+	csum := ComputeChecksum(sentence[delimiterLength(sentence):])
+	return sentence + "*" + strings.ToUpper(hex.EncodeToString([]byte{csum}))
+}
+
+// upperCaseChecksum reports whether the hex digits of the checksum of a sentence are upper case
+// (or digits), as the NMEA 0183 standard requires.
+func upperCaseChecksum(sentence string) bool {
+	for i := len(sentence) - 2; i >= 0 && i < len(sentence); i++ {
+		if sentence[i] >= 'a' && sentence[i] <= 'f' {
+			return false
+		}
+	}
+	return true
+}
+
+// hasChecksum reports whether a sentence carries a checksum field (*HH at its end).
+func hasChecksum(sentence string) bool {
+	return strings.IndexByte(sentence, '*') >= 0
+}
+
+// delimiterLength returns 1 if the sentence starts with a NMEA 0183 delimiter (! for AIS
+// sentences, $ for the rest), 0 otherwise.
+func delimiterLength(sentence string) int {
+	if len(sentence) > 0 && (sentence[0] == '!' || sentence[0] == '$') {
+		return 1
+	}
+	return 0
+}
@@ -17,7 +17,83 @@
 
 package aislib
 
-import "testing"
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNmea183ChecksumCheck(t *testing.T) {
+	cases := []struct {
+		sentence string
+		want     bool
+	}{
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", true},
+		{"AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", true}, // delimiter missing
+		{"$GPGLL,4916.45,N,12311.12,W,225444,A,*1D", true},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6E", false},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*ZZ", false},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6f", true}, // lower case
+		{"*6F", false},
+	}
+	for _, c := range cases {
+		got := Nmea183ChecksumCheck(c.sentence)
+		if got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("Nmea183ChecksumCheck(sentence string) for %q", c.sentence)
+		}
+	}
+}
+
+func TestComputeChecksum(t *testing.T) {
+	cases := []struct {
+		sentence string
+		want     byte
+	}{
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", 0x6F},
+		{"!AIVDM,2,1,5,A,533iFNT00003W;3G;384iT<T400000000000001?88?73v0ik0RC1H11H30H,0*44", 0x44},
+		{"!AIVDM,2,2,5,A,51CU0E2CkP0,2*0C", 0x0C},
+		{"$GPGLL,4916.45,N,12311.12,W,225444,A,*1D", 0x1D},
+	}
+	for _, c := range cases {
+		body := c.sentence[1:strings.IndexByte(c.sentence, '*')]
+		if got := ComputeChecksum(body); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("ComputeChecksum(body string) for %q", c.sentence)
+		}
+		if got := Nmea183ChecksumAppend(c.sentence[:len(c.sentence)-3]); got != c.sentence {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.sentence)
+			t.Errorf("Nmea183ChecksumAppend(sentence string) for %q", c.sentence)
+		}
+	}
+	if got := ComputeChecksum(""); got != 0 {
+		t.Errorf("ComputeChecksum(body string) of an empty body should be 0, got %d", got)
+	}
+}
+
+func TestStrictChecksumCase(t *testing.T) {
+	cases := []struct {
+		sentence        string
+		lenient, strict bool
+	}{
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6F", true, true},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6f", true, false},
+		{"!AIVDM,1,1,,B,38u<a<?PAA2>P:WfuAO9PW<P0PuQ,0*6E", false, false},
+	}
+	for _, c := range cases {
+		_, err := Router(c.sentence)
+		_, strictErr := Router(c.sentence, StrictChecksumCase())
+		_, envelopeErr := ParseEnvelope(c.sentence, StrictChecksumCase())
+		if (err == nil) != c.lenient || (strictErr == nil) != c.strict || (envelopeErr == nil) != c.strict {
+			fmt.Println("Got : ", err, strictErr, envelopeErr)
+			fmt.Println("Want: ", c.lenient, c.strict)
+			t.Errorf("StrictChecksumCase() for %q", c.sentence)
+		}
+	}
+}
 
 func BenchmarkNmea183ChecksumCheck(b *testing.B) {
 	for i := 0; i < b.N; i++ {
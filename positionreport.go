@@ -37,29 +37,65 @@ type PositionReport struct {
 	Second   uint8   // timestamp
 }
 
+// Position returns the coordinates of the report. It implements Positioned for all the
+// position reports that embed PositionReport.
+func (m PositionReport) Position() (lat, lon float64, ok bool) {
+	return m.Lat, m.Lon, positionAvailable(m.Lon, m.Lat)
+}
+
 // A ClassAPositionReport is a decoded AIS position message (messages of type 1, 2 or 3).
 // Please have a look at http://catb.org/gpsd/AIVDM.html and at
 // http://www.navcen.uscg.gov/?pageName=AISMessagesA
 type ClassAPositionReport struct {
 	PositionReport
-	RAIM     bool    // RAIM flag
-	Radio    uint32  // Radio status
-	Status   uint8   // navigation status (enumerated type)
-	Turn     float32 // rate of turn - ROT (sc - Special Calc I3)
-	Maneuver uint8   // maneuver indicator (enumerated)
+	RAIM            bool              // RAIM flag
+	Radio           uint32            // Radio status
+	Status          uint8             // navigation status (enumerated type)
+	Turn            float32           // rate of turn - ROT (sc - Special Calc I3)
+	SpecialManeuver ManeuverIndicator // special maneuver indicator (enumerated)
+
+	// Deprecated: use SpecialManeuver, Maneuver holds the same value.
+	Maneuver uint8
+}
+
+// A ManeuverIndicator tells if a vessel is engaged in a special maneuver, e.g in a regulated
+// passage or turning basin, as reported in Class A position reports.
+type ManeuverIndicator uint8
+
+// Special maneuver indicator codes
+const (
+	ManeuverNotAvailable ManeuverIndicator = iota
+	ManeuverNotEngaged
+	ManeuverEngaged
+)
+
+// String returns a description of the special maneuver indicator
+func (m ManeuverIndicator) String() string {
+	switch m {
+	case ManeuverNotAvailable:
+		return "not available"
+	case ManeuverNotEngaged:
+		return "no special maneuver"
+	case ManeuverEngaged:
+		return "special maneuver"
+	}
+	return "not defined"
 }
 
 // A ClassBPositionReport is a decoded AIS position message (type 18).
+// Only type 18 carries the capability flags of the Class B unit (CSUnit to Msg22). Type 19
+// (ExtendedClassBPositionReport) and type 24 (StaticDataReport) don't, so to tell a CS from
+// an SO unit one needs a type 18 report of the vessel.
 type ClassBPositionReport struct {
 	PositionReport
 	RAIM     bool   // RAIM flag
 	Radio    uint32 // Radio status
-	CSUnit   bool
-	Display  bool
-	DSC      bool
-	Band     bool
-	Msg22    bool
-	Assigned bool
+	CSUnit   bool   // Class B unit type: true for carrier-sense (CS), false for SOTDMA (SO)
+	Display  bool   // The unit has a display for messages
+	DSC      bool   // The unit has a DSC function (VHF voice radio)
+	Band     bool   // The unit can use the whole marine band, else only the AIS channels
+	Msg22    bool   // The unit accepts channel management (type 22) messages
+	Assigned bool   // The unit is in assigned mode
 }
 
 // A ExtendedClassBPositionReport is a decoded AIS position message (type 19).
@@ -73,6 +109,22 @@ type ExtendedClassBPositionReport struct {
 	ToStarboard uint8  // Dimension to starboard
 	EPFD        uint8  // Position Fix Type (enumeration declared at basestationreport.go)
 	RAIM        bool   // RAIM flag
+	DTE         bool   // Data terminal equipment not ready
+	Assigned    bool   // The unit is in assigned mode
+}
+
+// DisplayHeading returns the heading to orient the icon of the vessel on a display: the true heading
+// if available, else the course over ground if the vessel is moving (SOG above stationarySpeed
+// knots, e.g DefaultStationarySpeed), as the COG of a stationary vessel is noise. It returns false
+// if neither is available.
+func (m ClassAPositionReport) DisplayHeading(stationarySpeed float32) (float64, bool) {
+	switch {
+	case m.Heading < 360:
+		return float64(m.Heading), true
+	case m.Course < 360 && m.Speed != 1023 && m.Speed > stationarySpeed:
+		return float64(m.Course), true
+	}
+	return 0, false
 }
 
 // Navigation status codes
@@ -83,6 +135,38 @@ var NavigationStatusCodes = [...]string{
 	"status code reserved", "status code reserved", "AIS-SART is active", "Not defined",
 }
 
+// Navigation status codes with an inland specific meaning (regional use of reserved codes)
+var inlandNavigationStatusCodes = map[uint8]string{
+	11: "Power-driven vessel towing astern",
+	12: "Power-driven vessel pushing ahead or towing alongside",
+}
+
+// InlandNavigationalStatus returns the description of a navigation status code as used by
+// inland AIS, e.g on European rivers. Codes without an inland specific meaning are described
+// as in NavigationStatusCodes.
+func InlandNavigationalStatus(code uint8) string {
+	if s, ok := inlandNavigationStatusCodes[code]; ok {
+		return s
+	}
+	if int(code) < len(NavigationStatusCodes) {
+		return NavigationStatusCodes[code]
+	}
+	return "Not defined"
+}
+
+// StatusDescription returns the description of the navigation status of the report. If inland is
+// true the inland interpretation of the codes is used (see InlandNavigationalStatus), otherwise
+// the maritime one of NavigationStatusCodes.
+func (m ClassAPositionReport) StatusDescription(inland bool) string {
+	if inland {
+		return InlandNavigationalStatus(m.Status)
+	}
+	if int(m.Status) < len(NavigationStatusCodes) {
+		return NavigationStatusCodes[m.Status]
+	}
+	return "Not defined"
+}
+
 // DecodeClassAPositionReport decodes [the payload of] an AIS position message (type 1/2/3)
 func DecodeClassAPositionReport(payload string) (ClassAPositionReport, error) {
 	data := []byte(payload)
@@ -153,7 +237,8 @@ func DecodeClassAPositionReport(payload string) (ClassAPositionReport, error) {
 	m.Second = uint8(bitsToInt(137, 142, data))
 
 	//m.Maneuver = decodeAisChar(data[23])<<7>>6 | decodeAisChar(data[24])>>5
-	m.Maneuver = uint8(bitsToInt(143, 144, data))
+	m.SpecialManeuver = ManeuverIndicator(bitsToInt(143, 144, data))
+	m.Maneuver = uint8(m.SpecialManeuver)
 
 	//m.RAIM = false
 	//if decodeAisChar(data[24])<<6>>7 == 1 {
@@ -199,9 +284,6 @@ func DecodeClassBPositionReport(payload string) (ClassBPositionReport, error) {
 	m.Assigned = cbnBool(146, data)
 
 	m.RAIM = cbnBool(147, data)
-	if decodeAisChar(data[24])<<6>>7 == 1 {
-		m.RAIM = true
-	}
 
 	m.Radio = bitsToInt(148, 167, data)
 	return m, nil
@@ -243,9 +325,8 @@ func DecodeExtendedClassBPositionReport(payload string) (ExtendedClassBPositionR
 	m.ToStarboard = uint8(bitsToInt(295, 300, data))
 
 	m.EPFD = uint8(bitsToInt(301, 304, data))
-	m.RAIM = cbnBool(147, data)
-	if decodeAisChar(data[24])<<6>>7 == 1 {
-		m.RAIM = true
-	}
+	m.RAIM = cbnBool(305, data)
+	m.DTE = cbnBool(306, data)
+	m.Assigned = cbnBool(307, data)
 	return m, nil
 }
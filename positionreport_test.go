@@ -36,8 +36,9 @@ func TestDecodeClassAPositionReport(t *testing.T) {
 				PositionReport: PositionReport{
 					Type: 3, Repeat: 0, MMSI: 601041200, Speed: 8.1,
 					Accuracy: false, Lon: 31.130165, Lat: -29.784113333333334, Course: 243.4,
-					Heading: 230, Second: 16, RAIM: false, Radio: 135009},
-				Status: 15, Turn: -127, Maneuver: 0},
+					Heading: 230, Second: 16},
+				RAIM: false, Radio: 135009,
+				Status: 15, Turn: -127, SpecialManeuver: 0, Maneuver: 0},
 		},
 		{
 			"13P:v?h009Ogbr4NkiITkU>L089D",
@@ -45,8 +46,9 @@ func TestDecodeClassAPositionReport(t *testing.T) {
 				PositionReport: PositionReport{
 					Type: 1, Repeat: 0, MMSI: 235060799, Speed: 0.9,
 					Accuracy: false, Lon: -3.56725, Lat: 53.84251666666667, Course: 123,
-					Heading: 167, Second: 14, RAIM: false, Radio: 33364},
-				Status: 0, Turn: 0, Maneuver: 0},
+					Heading: 167, Second: 14},
+				RAIM: false, Radio: 33364,
+				Status: 0, Turn: 0, SpecialManeuver: 0, Maneuver: 0},
 		},
 		{
 			"13n@oD0PB@0IRqvQj@W;EppH088t19uvPT",
@@ -54,8 +56,9 @@ func TestDecodeClassAPositionReport(t *testing.T) {
 				PositionReport: PositionReport{
 					Type: 1, Repeat: 0, MMSI: 258226000, Speed: 14.4,
 					Accuracy: false, Lon: 5.580478333333334, Lat: 59.0441, Course: 290.3,
-					Heading: 284, Second: 12, RAIM: false, Radio: 33340},
-				Status: 0, Turn: -127, Maneuver: 0},
+					Heading: 284, Second: 12},
+				RAIM: false, Radio: 33340,
+				Status: 0, Turn: -127, SpecialManeuver: 0, Maneuver: 0},
 		},
 	}
 	for _, c := range cases {
@@ -68,7 +71,114 @@ func TestDecodeClassAPositionReport(t *testing.T) {
 	}
 }
 
+func TestManeuverIndicator(t *testing.T) {
+	// A type 1 report with every field not available, except the maneuver indicator
+	payload := armor(bitField(1, 6) + bitField(0, 2) + bitField(235060799, 30) + bitField(15, 4) +
+		bitField(-128, 8) + bitField(1023, 10) + bitField(0, 1) + bitField(181*600000, 28) +
+		bitField(91*600000, 27) + bitField(3600, 12) + bitField(511, 9) + bitField(60, 6) +
+		bitField(2, 2) + bitField(0, 3) + bitField(0, 1) + bitField(0, 19))
+	want := ClassAPositionReport{
+		PositionReport: PositionReport{
+			Type: 1, Repeat: 0, MMSI: 235060799, Speed: 1023,
+			Accuracy: false, Lon: 181, Lat: 91, Course: 360,
+			Heading: 511, Second: 60},
+		RAIM: false, Radio: 0,
+		Status: 15, Turn: -128, SpecialManeuver: ManeuverEngaged, Maneuver: 2}
+
+	got, _ := DecodeClassAPositionReport(payload)
+	if got != want {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeClassAPositionReport(payload string)")
+	}
+
+	cases := []struct {
+		maneuver ManeuverIndicator
+		want     string
+	}{
+		{ManeuverNotAvailable, "not available"},
+		{ManeuverNotEngaged, "no special maneuver"},
+		{ManeuverEngaged, "special maneuver"},
+		{3, "not defined"},
+	}
+	for _, c := range cases {
+		if got := c.maneuver.String(); got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("(ManeuverIndicator) String()")
+		}
+	}
+}
+
+func TestNavigationStatusDescription(t *testing.T) {
+	cases := []struct {
+		status   uint8
+		maritime string
+		inland   string
+	}{
+		{0, "Under way using engine", "Under way using engine"},
+		{11, "status code reserved", "Power-driven vessel towing astern"},
+		{12, "status code reserved", "Power-driven vessel pushing ahead or towing alongside"},
+		{14, "AIS-SART is active", "AIS-SART is active"},
+		{15, "Not defined", "Not defined"},
+	}
+	for _, c := range cases {
+		var m ClassAPositionReport
+		m.Status = c.status
+		if got := m.StatusDescription(false); got != c.maritime {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.maritime)
+			t.Errorf("(ClassAPositionReport) StatusDescription(inland bool) for maritime status %d", c.status)
+		}
+		if got := m.StatusDescription(true); got != c.inland {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.inland)
+			t.Errorf("(ClassAPositionReport) StatusDescription(inland bool) for inland status %d", c.status)
+		}
+	}
+	if got := InlandNavigationalStatus(16); got != "Not defined" {
+		t.Errorf("InlandNavigationalStatus(code uint8) for an out of range code, got %s", got)
+	}
+}
+
+func TestDisplayHeading(t *testing.T) {
+	cases := []struct {
+		heading uint16
+		course  float32
+		speed   float32
+		want    float64
+		ok      bool
+	}{
+		{123, 130.5, 10, 123, true},
+		{0, 130.5, 0, 0, true},
+		{511, 130.5, 10, 130.5, true},
+		{511, 130.5, 0.1, 0, false}, // Stationary, COG is noise
+		{511, 130.5, 1023, 0, false},
+		{511, 360, 10, 0, false},
+	}
+	for _, c := range cases {
+		var m ClassAPositionReport
+		m.Heading, m.Course, m.Speed = c.heading, c.course, c.speed
+		got, ok := m.DisplayHeading(DefaultStationarySpeed)
+		if got != c.want || ok != c.ok {
+			fmt.Println("Got : ", got, ok)
+			fmt.Println("Want: ", c.want, c.ok)
+			t.Errorf("(ClassAPositionReport) DisplayHeading(stationarySpeed float32)")
+		}
+	}
+	var m ClassAPositionReport
+	m.Heading, m.Course, m.Speed = 511, 130.5, 0.1
+	if got, ok := m.DisplayHeading(0.05); got != 130.5 || !ok {
+		t.Errorf("(ClassAPositionReport) DisplayHeading(stationarySpeed float32) for a lower threshold, got %v %v", got, ok)
+	}
+}
+
+// The per type decoding benchmarks report allocations, as decoding is the hot path of any
+// application. Decoding a message should not allocate, apart from its text fields (names,
+// call signs, etc.) and time.Time values. Any change to the bit extraction functions should
+// keep allocs/op the same and not increase ns/op by more than 10%.
 func BenchmarkDecodeClassAPositionReport(b *testing.B) {
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		DecodeClassAPositionReport("38u<a<?PAA2>P:WfuAO9PW<P0PuQ")
 	}
@@ -85,7 +195,8 @@ func TestDecodeClassBPositionReport(t *testing.T) {
 				PositionReport: PositionReport{
 					Type: 18, Repeat: 0, MMSI: 266119000, Speed: 0,
 					Accuracy: false, Lon: 18.085243333333334, Lat: 59.32718333333333, Course: 0,
-					Heading: 511, Second: 34, RAIM: true, Radio: 917510},
+					Heading: 511, Second: 34},
+				RAIM: true, Radio: 917510,
 				CSUnit: true, Display: false, DSC: true, Band: true, Msg22: true, Assigned: false},
 		},
 		{
@@ -94,7 +205,8 @@ func TestDecodeClassBPositionReport(t *testing.T) {
 				PositionReport: PositionReport{
 					Type: 18, Repeat: 0, MMSI: 265715530, Speed: 0,
 					Accuracy: true, Lon: 11.81546, Lat: 58.07772333333333, Course: 326.3,
-					Heading: 511, Second: 37, RAIM: true, Radio: 917510},
+					Heading: 511, Second: 37},
+				RAIM: true, Radio: 917510,
 				CSUnit: true, Display: false, DSC: true, Band: true, Msg22: false, Assigned: false},
 		},
 	}
@@ -106,9 +218,44 @@ func TestDecodeClassBPositionReport(t *testing.T) {
 			t.Errorf("DecodeClassBPositionReport(payload string)")
 		}
 	}
+
+	// RAIM is bit 147 alone, the first bit of the radio status after it is set here
+	bits := payloadBits(cases[0].payload)
+	want := cases[0].want
+	want.RAIM = false
+	if got, _ := DecodeClassBPositionReport(armor(bits[:147] + "0" + bits[148:])); got != want {
+		fmt.Println("Got : ", got)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeClassBPositionReport(payload string) without RAIM")
+	}
+}
+
+func TestDecodeExtendedClassBPositionReport(t *testing.T) {
+	bits := bitField(19, 6) + bitField(0, 2) + bitField(239123000, 30) + bitField(0, 8) +
+		bitField(55, 10) + bitField(1, 1) + bitField(14100000, 28) + bitField(22350000, 27) +
+		bitField(1234, 12) + bitField(123, 9) + bitField(41, 6) + bitField(0, 4) +
+		sixBitText("AURA", 20) + bitField(37, 8) + bitField(8, 9) + bitField(4, 9) + bitField(2, 6) + bitField(2, 6) +
+		bitField(1, 4) + bitField(1, 1) + bitField(0, 1) + bitField(1, 1) + bitField(0, 4)
+	want := ExtendedClassBPositionReport{
+		PositionReport: PositionReport{
+			Type: 19, Repeat: 0, MMSI: 239123000, Speed: 5.5,
+			Accuracy: true, Lon: 23.5, Lat: 37.25, Course: 123.4,
+			Heading: 123, Second: 41},
+		VesselName: "AURA", ShipType: 37, ToBow: 8, ToStern: 4, ToPort: 2, ToStarboard: 2,
+		EPFD: 1, RAIM: true, DTE: false, Assigned: true,
+	}
+
+	got, err := DecodeExtendedClassBPositionReport(armor(bits))
+	if err != nil || got != want {
+		fmt.Println("Got : ", got, err)
+		fmt.Println("Want: ", want)
+		t.Errorf("DecodeExtendedClassBPositionReport(payload string)")
+	}
 }
 
+// See BenchmarkDecodeClassAPositionReport for the acceptance threshold.
 func BenchmarkDecodeClassBPositionReport(b *testing.B) {
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		DecodeClassBPositionReport("B3ujWF0000DdVU8O:1H03wi5oP06")
 	}
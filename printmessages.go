@@ -35,14 +35,25 @@ func (m BaseStationReport) String() string {
 		raim = "in use"
 	}
 
+	longRange := "not requested"
+	if m.LongRange == true {
+		longRange = "requested"
+	}
+
+	title := "Base Station Report"
+	if m.Type == 11 {
+		title = "UTC/Date Response"
+	}
+
 	message :=
-		fmt.Sprintf("=== Base Station Report ===\n") +
+		fmt.Sprintf("=== %s ===\n", title) +
 			fmt.Sprintf(" Repeat       : %d\n", m.Repeat) +
 			fmt.Sprintf(" MMSI         : %09d [%s]\n", m.MMSI, DecodeMMSI(m.MMSI)) +
 			fmt.Sprintf(" Time         : %s\n", m.Time.String()) +
 			fmt.Sprintf(" Accuracy     : %s\n", accuracy) +
 			fmt.Sprintf(" Coordinates  : %s\n", CoordinatesDeg2Human(m.Lon, m.Lat)) +
 			fmt.Sprintf(" EPFD         : %s\n", EpfdFixTypes[m.EPFD]) +
+			fmt.Sprintf(" Long Range   : %s\n", longRange) +
 			fmt.Sprintf(" RAIM         : %s\n", raim)
 
 	return message
@@ -104,16 +115,6 @@ func (m ClassAPositionReport) String() string {
 		heading = "please report this to developer"
 	}
 
-	maneuver := ""
-	switch {
-	case m.Maneuver == 0:
-		maneuver = "not available"
-	case m.Maneuver == 1:
-		maneuver = "no special maneuver"
-	case m.Maneuver == 2:
-		maneuver = "special maneuver"
-	}
-
 	raim := "not in use"
 	if m.RAIM == true {
 		raim = "in use"
@@ -130,7 +131,7 @@ func (m ClassAPositionReport) String() string {
 			fmt.Sprintf(" Coordinates  : %s\n", CoordinatesDeg2Human(m.Lon, m.Lat)) +
 			fmt.Sprintf(" Course (COG) : %s\n", course) +
 			fmt.Sprintf(" Heading (HDG): %s\n", heading) +
-			fmt.Sprintf(" Manuever ind.: %s\n", maneuver) +
+			fmt.Sprintf(" Manuever ind.: %s\n", m.SpecialManeuver) +
 			fmt.Sprintf(" RAIM         : %s\n", raim)
 
 	return message
@@ -262,3 +263,205 @@ func (m BinaryBroadcast) String() string {
 
 	return message
 }
+
+// PrintBinaryAddressed returns a string with some data for a Binary Addressed message
+func (m BinaryAddressed) String() string {
+
+	message :=
+		fmt.Sprintf("=== Binary Addressed ===\n") +
+			fmt.Sprintf(" Repeat       : %d\n", m.Repeat) +
+			fmt.Sprintf(" MMSI         : %09d [%s]\n", m.MMSI, DecodeMMSI(m.MMSI)) +
+			fmt.Sprintf(" Sequence     : %d\n", m.Sequence) +
+			fmt.Sprintf(" Destination  : %09d [%s]\n", m.DestMMSI, DecodeMMSI(m.DestMMSI)) +
+			fmt.Sprintf(" Retransmit   : %t\n", m.Retransmit) +
+			fmt.Sprintf(" DAC-FID      : %d-%d (%s)\n", m.DAC, m.FID, BinaryBroadcastType[int(m.DAC)][int(m.FID)])
+
+	return message
+}
+
+// PrintLongRangePositionReport returns a formatted string with the detailed data of a AIS long range
+// position report (type 27).
+func (m LongRangePositionReport) String() string {
+	speed := fmt.Sprintf("%d knots", m.Speed)
+	if m.Speed == 63 {
+		speed = "not available"
+	}
+
+	accuracy := "High accuracy (<10m)"
+	if m.Accuracy == false {
+		accuracy = "Low accuracy (>10m)"
+	}
+
+	raim := "not in use"
+	if m.RAIM == true {
+		raim = "in use"
+	}
+
+	course := fmt.Sprintf("%d°", m.Course)
+	if m.Course >= 360 {
+		course = "not available"
+	}
+
+	latency := "current GNSS position"
+	if m.PositionLatency == true {
+		latency = "older than 5 seconds"
+	}
+
+	message :=
+		fmt.Sprintf("=== Long Range Position Report ===\n") +
+			fmt.Sprintf(" Repeat       : %d\n", m.Repeat) +
+			fmt.Sprintf(" MMSI         : %09d [%s]\n", m.MMSI, DecodeMMSI(m.MMSI)) +
+			fmt.Sprintf(" Nav Status   : %s\n", NavigationStatusCodes[m.Status]) +
+			fmt.Sprintf(" Accuracy     : %s\n", accuracy) +
+			fmt.Sprintf(" RAIM         : %s\n", raim) +
+			fmt.Sprintf(" Coordinates  : %s\n", CoordinatesDeg2Human(m.Lon, m.Lat)) +
+			fmt.Sprintf(" Speed (SOG)  : %s\n", speed) +
+			fmt.Sprintf(" Course (COG) : %s\n", course) +
+			fmt.Sprintf(" Position     : %s\n", latency)
+
+	return message
+}
+
+// PrintAddressedSafetyMessage returns a formatted string of an Addressed Safety Related Message
+func (m AddressedSafetyMessage) String() string {
+
+	message :=
+		fmt.Sprintf("=== Addressed Safety Related Message ===\n") +
+			fmt.Sprintf(" Repeat       : %d\n", m.Repeat) +
+			fmt.Sprintf(" MMSI         : %09d [%s]\n", m.MMSI, DecodeMMSI(m.MMSI)) +
+			fmt.Sprintf(" Sequence     : %d\n", m.Sequence) +
+			fmt.Sprintf(" Destination  : %09d [%s]\n", m.DestMMSI, DecodeMMSI(m.DestMMSI)) +
+			fmt.Sprintf(" Retransmit   : %t\n", m.Retransmit) +
+			fmt.Sprintf(" Text         : %s\n", m.Text)
+
+	return message
+}
+
+// PrintChannelManagement returns a formatted string of a Channel Management message
+func (m ChannelManagement) String() string {
+	power := "high"
+	if m.LowPower == true {
+		power = "low"
+	}
+
+	target := fmt.Sprintf(" Region NE    : %s\n", CoordinatesDeg2Human(m.NELon, m.NELat)) +
+		fmt.Sprintf(" Region SW    : %s\n", CoordinatesDeg2Human(m.SWLon, m.SWLat))
+	if m.Addressed == true {
+		target = fmt.Sprintf(" Destination 1: %09d [%s]\n", m.DestMMSI1, DecodeMMSI(m.DestMMSI1)) +
+			fmt.Sprintf(" Destination 2: %09d [%s]\n", m.DestMMSI2, DecodeMMSI(m.DestMMSI2))
+	}
+
+	message :=
+		fmt.Sprintf("=== Channel Management ===\n") +
+			fmt.Sprintf(" Repeat       : %d\n", m.Repeat) +
+			fmt.Sprintf(" MMSI         : %09d [%s]\n", m.MMSI, DecodeMMSI(m.MMSI)) +
+			fmt.Sprintf(" Channels     : %d, %d\n", m.ChannelA, m.ChannelB) +
+			fmt.Sprintf(" Tx/Rx Mode   : %s\n", m.TxRx) +
+			fmt.Sprintf(" Power        : %s\n", power) +
+			target +
+			fmt.Sprintf(" Zone Size    : %d nm\n", m.ZoneSize+1)
+
+	return message
+}
+
+// PrintGroupAssignment returns a formatted string of a Group Assignment Command
+func (m GroupAssignment) String() string {
+	quiet := "none"
+	if m.QuietTime > 0 {
+		quiet = fmt.Sprintf("%d minutes", m.QuietTime)
+	}
+
+	message :=
+		fmt.Sprintf("=== Group Assignment Command ===\n") +
+			fmt.Sprintf(" Repeat       : %d\n", m.Repeat) +
+			fmt.Sprintf(" MMSI         : %09d [%s]\n", m.MMSI, DecodeMMSI(m.MMSI)) +
+			fmt.Sprintf(" Region NE    : %s\n", CoordinatesDeg2Human(m.NELon, m.NELat)) +
+			fmt.Sprintf(" Region SW    : %s\n", CoordinatesDeg2Human(m.SWLon, m.SWLat)) +
+			fmt.Sprintf(" Station Type : %s\n", StationTypes[m.StationType]) +
+			fmt.Sprintf(" Ship Type    : %s\n", ShipType[int(m.ShipType)]) +
+			fmt.Sprintf(" Tx/Rx Mode   : %s\n", m.TxRx) +
+			fmt.Sprintf(" Interval     : %s\n", ReportingIntervals[m.Interval]) +
+			fmt.Sprintf(" Quiet Time   : %s\n", quiet)
+
+	return message
+}
+
+// PrintSARAircraftPositionReport returns a formatted string with the detailed data of a AIS SAR aircraft
+// position report (type 9).
+func (m SARAircraftPositionReport) String() string {
+	altitude := fmt.Sprintf("%d m", m.Altitude)
+	switch m.Altitude {
+	case 4094:
+		altitude = ">=4094 m"
+	case 4095:
+		altitude = "not available"
+	}
+
+	speed := fmt.Sprintf("%d knots", m.Speed)
+	switch m.Speed {
+	case 1022:
+		speed = ">=1022 knots"
+	case 1023:
+		speed = "not available"
+	}
+
+	accuracy := "High accuracy (<10m)"
+	if m.Accuracy == false {
+		accuracy = "Low accuracy (>10m)"
+	}
+
+	course := fmt.Sprintf("%.1f°", m.Course)
+	if m.Course >= 360 {
+		course = "not available"
+	}
+
+	raim := "not in use"
+	if m.RAIM == true {
+		raim = "in use"
+	}
+
+	message :=
+		fmt.Sprintf("=== SAR Aircraft Position Report ===\n") +
+			fmt.Sprintf(" Repeat       : %d\n", m.Repeat) +
+			fmt.Sprintf(" MMSI         : %09d [%s]\n", m.MMSI, DecodeMMSI(m.MMSI)) +
+			fmt.Sprintf(" Altitude     : %s\n", altitude) +
+			fmt.Sprintf(" Speed (SOG)  : %s\n", speed) +
+			fmt.Sprintf(" Accuracy     : %s\n", accuracy) +
+			fmt.Sprintf(" Coordinates  : %s\n", CoordinatesDeg2Human(m.Lon, m.Lat)) +
+			fmt.Sprintf(" Course (COG) : %s\n", course) +
+			fmt.Sprintf(" Timestamp    : %d\n", m.Second) +
+			fmt.Sprintf(" Assigned     : %t\n", m.Assigned) +
+			fmt.Sprintf(" RAIM         : %s\n", raim)
+
+	return message
+}
+
+// String returns a formatted string with the detailed data of an AIS Aid-to-Navigation report (type 21).
+func (m AidToNavigationReport) String() string {
+	accuracy := "High accuracy (<10m)"
+	if m.Accuracy == false {
+		accuracy = "Low accuracy (>10m)"
+	}
+
+	offPosition := "not available"
+	if off, valid := m.OffPosition(); valid {
+		offPosition = fmt.Sprintf("%t", off)
+	}
+
+	message :=
+		fmt.Sprintf("=== Aid-to-Navigation Report ===\n") +
+			fmt.Sprintf(" Repeat       : %d\n", m.Repeat) +
+			fmt.Sprintf(" MMSI         : %09d [%s]\n", m.MMSI, DecodeMMSI(m.MMSI)) +
+			fmt.Sprintf(" Aid Type     : %s\n", AidTypeName(m.AidType)) +
+			fmt.Sprintf(" Category     : %s\n", m.Category()) +
+			fmt.Sprintf(" Name         : %s\n", m.Name) +
+			fmt.Sprintf(" Accuracy     : %s\n", accuracy) +
+			fmt.Sprintf(" Coordinates  : %s\n", CoordinatesDeg2Human(m.Lon, m.Lat)) +
+			fmt.Sprintf(" EPFD         : %s\n", EpfdFixTypes[m.EPFD]) +
+			fmt.Sprintf(" Timestamp    : %d\n", m.Second) +
+			fmt.Sprintf(" Off Position : %s\n", offPosition) +
+			fmt.Sprintf(" Virtual      : %t\n", m.Virtual) +
+			fmt.Sprintf(" Assigned     : %t\n", m.Assigned)
+
+	return message
+}
@@ -19,7 +19,6 @@ package aislib
 
 import (
 	"errors"
-	"strconv"
 	"strings"
 )
 
@@ -40,66 +39,175 @@ type FailedSentence struct {
 	Issue    string
 }
 
-// Router accepts AIS radio sentences and process them. It checks their checksum,
-// and AIS identifiers. If they are valid it tries to assemble the payload if it spans
-// on multiple sentences. Upon success it returns the AIS Message at the out channel.
-// Failed sentences go to the err channel.
-// If the in channel is closed, then it sends a message with type 255 at the out channel.
-// Your function can check for this message to know when it is safe to exit the program.
-func Router(sentence string) (*Message, error) {
-	count, ccount, padding := 0, 0, 0
-	size, id := "0", "0"
-	payload := ""
-	var cache [5]string
-	var err error
-	aisIdentifiers := map[string]bool{
-		"ABVD": true, "ADVD": true, "AIVD": true, "ANVD": true, "ARVD": true,
-		"ASVD": true, "ATVD": true, "AXVD": true, "BSVD": true, "SAVD": true,
+// Errors returned by Router and ParseEnvelope for sentences they can't process.
+// ErrMalformed is for sentences that have a valid checksum and AIS identifier but aren't
+// structured as AIS sentences, e.g they lack fields or carry an empty payload. When a field is
+// invalid (e.g a non numeric fragment count) the error wraps ErrMalformed and names the field,
+// so check it with errors.Is.
+// ErrHeartbeat is for keep-alive and comment lines (see IsHeartbeat), which may be skipped
+// like empty lines or logged for debugging.
+// ErrMultipart is for the fragments of messages that span across sentences, which Router
+// doesn't assemble.
+var (
+	ErrEmpty     = errors.New("empty line")
+	ErrHeartbeat = errors.New("heartbeat line")
+	ErrChecksum  = errors.New("checksum failed")
+	ErrNotAIS    = errors.New("sentence isn't AIVDM/AIVDO")
+	ErrMalformed = errors.New("malformed sentence")
+	ErrMultipart = errors.New("span sentence, multi-sentence messages aren't assembled")
+
+	// Deprecated: Router doesn't assemble span sentences, it returns ErrMultipart for all of them.
+	ErrOutOfOrder = errors.New("incomplete/out of order span sentence")
+)
+
+// FailureReason returns a short, stable label for an error of Router or ParseEnvelope, suitable
+// for metric labels or log fields: "empty", "heartbeat", "checksum", "not-ais", "malformed",
+// "multipart" or "out-of-order".
+// Any other error is labeled "other" and a nil error "".
+func FailureReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrEmpty):
+		return "empty"
+	case errors.Is(err, ErrHeartbeat):
+		return "heartbeat"
+	case errors.Is(err, ErrChecksum):
+		return "checksum"
+	case errors.Is(err, ErrNotAIS):
+		return "not-ais"
+	case errors.Is(err, ErrMalformed):
+		return "malformed"
+	case errors.Is(err, ErrMultipart):
+		return "multipart"
+	case errors.Is(err, ErrOutOfOrder):
+		return "out-of-order"
+	}
+	return "other"
+}
+
+// aisIdentifiers are the talker IDs (and the first letters of the VDM/VDO formatter)
+// of the sentences that carry AIS messages.
+var aisIdentifiers = map[string]bool{
+	"ABVD": true, "ADVD": true, "AIVD": true, "ANVD": true, "ARVD": true,
+	"ASVD": true, "ATVD": true, "AXVD": true, "BSVD": true, "SAVD": true,
+}
+
+// AISIdentifiers returns a copy of the default set of accepted AIS identifiers,
+// e.g to extend it and pass it to WithIdentifiers.
+func AISIdentifiers() map[string]bool {
+	set := make(map[string]bool, len(aisIdentifiers))
+	for k, v := range aisIdentifiers {
+		set[k] = v
+	}
+	return set
+}
+
+// A RouterOption changes how Router and ParseEnvelope process sentences.
+type RouterOption func(*routerOptions)
+
+type routerOptions struct {
+	identifiers        map[string]bool
+	strictChecksumCase bool
+	requireChecksum    bool
+}
+
+// newRouterOptions returns the default options with the given options applied.
+func newRouterOptions(options []RouterOption) routerOptions {
+	o := routerOptions{identifiers: aisIdentifiers, requireChecksum: true}
+	for _, option := range options {
+		option(&o)
+	}
+	return o
+}
+
+// WithIdentifiers replaces the set of AIS identifiers that sentences are accepted for.
+// An identifier is the 4-character talker portion after the leading delimiter, the talker ID
+// and the first two letters of the formatter, e.g AIVD for !AIVDM and !AIVDO sentences.
+// Some experimental feeds use identifiers not in the default set (see AISIdentifiers).
+func WithIdentifiers(set map[string]bool) RouterOption {
+	return func(o *routerOptions) {
+		o.identifiers = set
+	}
+}
+
+// StrictChecksumCase rejects sentences whose checksum has lower case hex digits (e.g *6f), as the
+// NMEA 0183 standard requires upper case. By default both cases are accepted.
+func StrictChecksumCase() RouterOption {
+	return func(o *routerOptions) {
+		o.strictChecksumCase = true
+	}
+}
+
+// RequireChecksum sets whether sentences must carry a checksum (the default). If require is false,
+// sentences without a checksum (e.g hand-crafted or simulator output) are accepted as if their
+// checksum passed. Sentences that do carry a checksum are still verified.
+func RequireChecksum(require bool) RouterOption {
+	return func(o *routerOptions) {
+		o.requireChecksum = require
+	}
+}
+
+// checksumCheck checks the checksum of a sentence, and its case if StrictChecksumCase is set.
+// Sentences without a checksum pass only if RequireChecksum is false.
+func (o routerOptions) checksumCheck(sentence string) bool {
+	if !o.requireChecksum && !hasChecksum(sentence) {
+		return true
+	}
+	if o.strictChecksumCase && !upperCaseChecksum(sentence) {
+		return false
 	}
+	return Nmea183ChecksumCheck(sentence)
+}
+
+// Router accepts an AIS radio sentence and processes it. It checks its checksum, AIS identifier
+// and envelope fields and if they are valid it returns the AIS Message the sentence carries.
+// Router keeps no state between calls, so it doesn't assemble messages that span across
+// sentences: each of their fragments fails with ErrMultipart. Failed sentences return one of
+// the errors above (see FailureReason).
+// Options (e.g WithIdentifiers) change which sentences are accepted.
+func Router(sentence string, options ...RouterOption) (*Message, error) {
+	o := newRouterOptions(options)
 	if len(sentence) == 0 { // Do not process empty lines
-		return nil, errors.New("empty line")
+		return nil, ErrEmpty
+	}
+	if IsHeartbeat(sentence) {
+		return nil, ErrHeartbeat
 	}
 	tokens := strings.Split(sentence, ",") // I think this takes the major portion of time for this function (after benchmarking)
 
-	if !Nmea183ChecksumCheck(sentence) { // Checksum check
-		return nil, errors.New("checksum failed")
-	}
-
-	if !aisIdentifiers[tokens[0][1:5]] { // Check for valid AIS identifier
-		return nil, errors.New("sentence isn't AIVDM/AIVDO")
-	}
-
-	if tokens[1] == "1" { // One sentence message, process it immediately
-		return &Message{MessageType(tokens[5]), tokens[5], uint8(padding)}, nil
-	} else { // Message spans across sentences.
-		ccount, err = strconv.Atoi(tokens[2])
-		if err != nil {
-			return nil, errors.New("here: " + tokens[2])
-		}
-		if ccount != count+1 || // If there are sentences with wrong seq.number in cache send them as failed
-			(tokens[3] != id && count != 0) || // If there are sentences with different sequence id in cache , send old parts as failed
-			(tokens[1] != size && count != 0) { // If there messages with wrong size in cache, send them as failed
-			for i := 0; i < count; i++ {
-				return nil, errors.New("incomplete/out of order span sentence")
-			}
-			if ccount != 1 { // The current one is invalid too
-				return nil, errors.New("incomplete/out of order span sentence")
-			}
-			count = 0
-			payload = ""
-		}
-		payload += tokens[5]
-		cache[ccount-1] = sentence
-		count++
-		if ccount == 1 { // First message in sequence, get size and id
-			size = tokens[1]
-			id = tokens[3]
-		} else if size == tokens[2] && count == ccount { // Last message in sequence, send it and clean up.
-			padding, _ = strconv.Atoi(tokens[6][:1])
-			count = 0
-			payload = ""
-			return &Message{MessageType(payload), payload, uint8(padding)}, nil
-		}
-	}
-	return &Message{255, "", 0}, nil
+	if !o.checksumCheck(sentence) { // Checksum check
+		return nil, ErrChecksum
+	}
+
+	// Check for valid AIS identifier. Some sources omit the delimiter.
+	start := delimiterLength(sentence)
+	if len(tokens[0]) < start+4 || !o.identifiers[tokens[0][start:start+4]] {
+		return nil, ErrNotAIS
+	}
+
+	if len(tokens) != 7 || len(tokens[5]) == 0 {
+		return nil, ErrMalformed
+	}
+
+	fragments, err := parseEnvelopeInt("fragment count", tokens[1], 1, 9)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := parseEnvelopeInt("fragment number", tokens[2], 1, fragments); err != nil {
+		return nil, err
+	}
+	fill := tokens[6]
+	if i := strings.IndexByte(fill, '*'); i >= 0 {
+		fill = fill[:i]
+	}
+	padding, err := parseEnvelopeInt("fill bits", fill, 0, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	if fragments > 1 { // Message spans across sentences, we can't assemble it
+		return nil, ErrMultipart
+	}
+	return &Message{MessageType(tokens[5]), tokens[5], uint8(padding)}, nil
 }
@@ -0,0 +1,60 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+)
+
+// An AddressedSafetyMessage is a decoded AIS Addressed Safety Related Message (message type 12).
+// The receiving station acknowledges it (type 13) with the sender's Sequence number.
+type AddressedSafetyMessage struct {
+	Repeat     uint8
+	MMSI       uint32
+	Sequence   uint8 // Sequence number
+	DestMMSI   uint32
+	Retransmit bool
+	Text       string
+}
+
+// DecodeAddressedSafetyMessage decodes [the payload of] an AIS Addressed Safety Related Message (type 12).
+// The text is variable length, up to 156 characters; its length is derived from the payload.
+func DecodeAddressedSafetyMessage(payload string) (AddressedSafetyMessage, error) {
+	data := []byte(payload)
+	var m AddressedSafetyMessage
+
+	mType := decodeAisChar(data[0])
+	if mType != 12 {
+		return m, errors.New("Message isn't Addressed Safety Related Message (type 12).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+
+	m.MMSI = bitsToInt(8, 37, data)
+
+	m.Sequence = uint8(bitsToInt(38, 39, data))
+	m.DestMMSI = bitsToInt(40, 69, data)
+	m.Retransmit = cbnBool(70, data)
+
+	// Text starts at bit 72, only whole characters count. Fill bits can't complete a character.
+	if chars := (len(data)*6 - 72) / 6; chars > 0 {
+		m.Text = bitsToString(72, 72+chars*6-1, data)
+	}
+
+	return m, nil
+}
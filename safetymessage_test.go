@@ -0,0 +1,55 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeAddressedSafetyMessage(t *testing.T) {
+	short := bitField(12, 6) + bitField(0, 2) + bitField(237000000, 30) + bitField(3, 2) +
+		bitField(239876000, 30) + bitField(1, 1) + bitField(0, 1) + sixBitText("MOB", 3)
+
+	cases := []struct {
+		payload string
+		want    AddressedSafetyMessage
+	}{
+		{
+			"<02:oP0kKcv0@<51C5PB5@?BDPD?P:?2?EB7PDB16693P381>>5<PikP",
+			AddressedSafetyMessage{
+				Repeat: 0, MMSI: 2275200, Sequence: 0, DestMMSI: 215724000, Retransmit: false,
+				Text: "PLEASE REPORT TO JOBOURG TRAFFIC CHANNEL 13",
+			},
+		},
+		{
+			armor(short),
+			AddressedSafetyMessage{
+				Repeat: 0, MMSI: 237000000, Sequence: 3, DestMMSI: 239876000, Retransmit: true, Text: "MOB",
+			},
+		},
+	}
+	for _, c := range cases {
+		got, _ := DecodeAddressedSafetyMessage(c.payload)
+		if got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeAddressedSafetyMessage(payload string)")
+		}
+	}
+}
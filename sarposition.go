@@ -0,0 +1,78 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"errors"
+)
+
+// A SARAircraftPositionReport is a decoded AIS Standard SAR Aircraft Position Report (message type 9).
+// Please have a look at http://catb.org/gpsd/AIVDM.html
+type SARAircraftPositionReport struct {
+	Repeat    uint8
+	MMSI      uint32
+	Altitude  uint16  // meters, 4095 means not available, 4094 means 4094 meters or higher
+	Speed     uint16  // knots, 1023 means not available, 1022 means 1022 knots or higher
+	Accuracy  bool    // position accuracy
+	Lon       float64 // (sc I4)
+	Lat       float64 // (sc I4)
+	Course    float32 // course over ground - COG, 360 means not available
+	Second    uint8   // timestamp
+	DTE       bool    // Data terminal ready, it is inverted: false means ready
+	Assigned  bool    // Assigned mode flag
+	RAIM      bool    // RAIM flag
+	Radio     uint32  // Radio status
+	CommState CommState
+}
+
+// DecodeSARAircraftPositionReport decodes [the payload of] an AIS SAR aircraft position report (type 9).
+// Its communication state is SOTDMA or ITDMA, depending on the communication state selector flag.
+func DecodeSARAircraftPositionReport(payload string) (SARAircraftPositionReport, error) {
+	data := []byte(payload)
+	var m SARAircraftPositionReport
+
+	mType := decodeAisChar(data[0])
+	if mType != 9 {
+		return m, errors.New("Message isn't SAR Aircraft Position Report (type 9).")
+	}
+
+	m.Repeat = uint8(bitsToInt(6, 7, data))
+	m.MMSI = bitsToInt(8, 37, data)
+
+	m.Altitude = uint16(bitsToInt(38, 49, data))
+	m.Speed = uint16(bitsToInt(50, 59, data))
+	m.Accuracy = cbnBool(60, data)
+	m.Lon, m.Lat = cbnCoordinates(61, data)
+	m.Course = float32(bitsToInt(116, 127, data)) / 10
+	m.Second = uint8(bitsToInt(128, 133, data))
+
+	// Bits 134-141 are reserved for regional applications
+	m.DTE = cbnBool(142, data)
+	m.Assigned = cbnBool(146, data)
+	m.RAIM = cbnBool(147, data)
+
+	m.Radio = bitsToInt(149, 167, data)
+	m.CommState = decodeCommState(149, cbnBool(148, data), data)
+
+	return m, nil
+}
+
+// Position returns the coordinates of the aircraft, it implements Positioned.
+func (m SARAircraftPositionReport) Position() (lat, lon float64, ok bool) {
+	return m.Lat, m.Lon, positionAvailable(m.Lon, m.Lat)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeSARAircraftPositionReport(t *testing.T) {
+	sotdma := "91b55wi;hbOS@OdQAC062Ch2089h"
+	// Same report, with the selector flag set and an ITDMA communication state:
+	// UTC indirect, slot increment 1234, 2 slots and keep flag set.
+	itdma := armor(payloadBits(sotdma)[:148] + "1" + bitField(1, 2) + bitField(1234, 13) + bitField(2, 3) + "1")
+
+	want := SARAircraftPositionReport{
+		Repeat: 0, MMSI: 111232511, Altitude: 303, Speed: 42, Accuracy: false, Lon: -6.2788433333333336,
+		Lat: 58.144, Course: 154.5, Second: 15, DTE: true, Assigned: false, RAIM: false,
+	}
+	cases := []struct {
+		payload   string
+		radio     uint32
+		commState CommState
+	}{
+		{sotdma, 33392, CommState{ITDMA: false, SyncState: 0, SlotTimeout: 2, SlotNumber: 624}},
+		{itdma, 150821, CommState{ITDMA: true, SyncState: 1, SlotIncrement: 1234, NumberOfSlots: 2, KeepFlag: true}},
+	}
+	for _, c := range cases {
+		want.Radio, want.CommState = c.radio, c.commState
+		got, _ := DecodeSARAircraftPositionReport(c.payload)
+		if got != want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", want)
+			t.Errorf("DecodeSARAircraftPositionReport(payload string)")
+		}
+	}
+}
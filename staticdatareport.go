@@ -30,9 +30,10 @@ type StaticDataReport struct {
 	VesselName string
 	//PartB
 	ShipType      uint8
-	VendorID      string
+	VendorID      string // 3 characters, manufacturer's mnemonic code
 	UnitModelCode uint8
 	SerialNumber  uint32
+	VendorString  string // 7 characters, vendor ID, unit model and serial number as text (older revisions)
 	CallSign      string
 	// optional with MothershipMMSI
 	ToBow          uint16 // Dimension to bow
@@ -40,6 +41,7 @@ type StaticDataReport struct {
 	ToPort         uint8  // Dimension to port
 	ToStarboard    uint8  // Dimension to starboard
 	MothershipMMSI uint32
+	EPFD           uint8 // Position Fix Type (enumeration declared at basestationreport.go), 0 in older revisions
 }
 
 // DecodeStaticDataReport decodes the payload of a Type 24 AIS message
@@ -61,13 +63,14 @@ func DecodeStaticDataReport(payload string) (StaticDataReport, error) {
 
 	m.PartNo = uint8(bitsToInt(38, 39, data))
 	if m.PartNo == 0 {
-		m.VesselName = bitsToString(112, 231, data)
+		m.VesselName = bitsToString(40, 159, data)
 	} else {
 		m.ShipType = uint8(bitsToInt(40, 47, data))
 		m.VendorID = bitsToString(48, 65, data)
 
 		m.UnitModelCode = uint8(bitsToInt(66, 69, data))
 		m.SerialNumber = uint32(bitsToInt(70, 89, data))
+		m.VendorString = bitsToString(48, 89, data)
 		m.CallSign = bitsToString(90, 131, data)
 
 		// its an auxiliary craft
@@ -79,7 +82,40 @@ func DecodeStaticDataReport(payload string) (StaticDataReport, error) {
 			m.ToPort = uint8(bitsToInt(150, 155, data))
 			m.ToStarboard = uint8(bitsToInt(156, 161, data))
 		}
+		m.EPFD = uint8(bitsToInt(162, 165, data))
 	}
 
 	return m, nil
 }
+
+// Merge fills m with the fields that other provides: the vessel name if other is a part A,
+// or the part B fields otherwise. Fields the other part doesn't carry are left untouched, so a
+// part A and a part B can be merged in any order into a complete record. Repeat and PartNo are
+// those of m. It returns an error if the two reports aren't from the same MMSI.
+func (m *StaticDataReport) Merge(other StaticDataReport) error {
+	if m.MMSI != other.MMSI {
+		return errors.New("Static data reports are from different vessels (MMSI).")
+	}
+
+	switch other.PartNo {
+	case 0:
+		m.VesselName = other.VesselName
+	case 1:
+		m.ShipType = other.ShipType
+		m.VendorID = other.VendorID
+		m.UnitModelCode = other.UnitModelCode
+		m.SerialNumber = other.SerialNumber
+		m.VendorString = other.VendorString
+		m.CallSign = other.CallSign
+		m.ToBow = other.ToBow
+		m.ToStern = other.ToStern
+		m.ToPort = other.ToPort
+		m.ToStarboard = other.ToStarboard
+		m.MothershipMMSI = other.MothershipMMSI
+		m.EPFD = other.EPFD
+	default:
+		return errors.New("Static data report part number isn't A or B.")
+	}
+
+	return nil
+}
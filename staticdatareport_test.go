@@ -0,0 +1,95 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeStaticDataReport(t *testing.T) {
+	cases := []struct {
+		payload string
+		want    StaticDataReport
+	}{
+		{
+			"H42O55i18tMET00000000000000",
+			StaticDataReport{Repeat: 0, MMSI: 271041815, PartNo: 0, VesselName: "PROGUY"},
+		},
+		{
+			"H42O55lti4hhhilD3nink000?050",
+			StaticDataReport{
+				Repeat: 0, MMSI: 271041815, PartNo: 1, ShipType: 60, VendorID: "1D0", UnitModelCode: 12,
+				SerialNumber: 199796, VendorString: "1D00014", CallSign: "TC6163", ToBow: 0, ToStern: 15, ToPort: 0, ToStarboard: 5,
+			},
+		},
+		{
+			// Same as above with the EPFD of recent revisions (GPS)
+			armor(payloadBits("H42O55lti4hhhilD3nink000?050")[:162] + bitField(1, 4) + bitField(0, 2)),
+			StaticDataReport{
+				Repeat: 0, MMSI: 271041815, PartNo: 1, ShipType: 60, VendorID: "1D0", UnitModelCode: 12,
+				SerialNumber: 199796, VendorString: "1D00014", CallSign: "TC6163", ToBow: 0, ToStern: 15, ToPort: 0, ToStarboard: 5,
+				EPFD: 1,
+			},
+		},
+	}
+	for _, c := range cases {
+		got, _ := DecodeStaticDataReport(c.payload)
+		if got != c.want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DecodeStaticDataReport(payload string)")
+		}
+	}
+}
+
+func TestStaticDataReportMerge(t *testing.T) {
+	partA, _ := DecodeStaticDataReport("H42O55i18tMET00000000000000")
+	partB, _ := DecodeStaticDataReport("H42O55lti4hhhilD3nink000?050")
+	want := StaticDataReport{
+		Repeat: 0, MMSI: 271041815, VesselName: "PROGUY", ShipType: 60, VendorID: "1D0", UnitModelCode: 12,
+		SerialNumber: 199796, VendorString: "1D00014", CallSign: "TC6163", ToBow: 0, ToStern: 15, ToPort: 0, ToStarboard: 5,
+	}
+
+	for _, parts := range [][2]StaticDataReport{{partA, partB}, {partB, partA}} {
+		got := parts[0]
+		if err := got.Merge(parts[1]); err != nil {
+			t.Errorf("(*StaticDataReport) Merge(other StaticDataReport)")
+		}
+		want.PartNo = parts[0].PartNo
+		if got != want {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", want)
+			t.Errorf("(*StaticDataReport) Merge(other StaticDataReport)")
+		}
+	}
+
+	other := partB
+	other.MMSI = 271041816
+	if err := partA.Merge(other); err == nil || partA.CallSign != "" {
+		t.Errorf("(*StaticDataReport) Merge(other StaticDataReport)")
+	}
+}
+
+// See BenchmarkDecodeClassAPositionReport for the acceptance threshold.
+func BenchmarkDecodeStaticDataReport(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		DecodeStaticDataReport("H42O55lti4hhhilD3nink000?050")
+	}
+}
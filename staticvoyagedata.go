@@ -20,6 +20,7 @@ package aislib
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -41,7 +42,8 @@ type StaticVoyageData struct {
 	ETA         time.Time // Not reliable
 	Draught     uint8     // Meters/10
 	Destination string
-	DTE         bool
+	DTE         bool // DTE flag as transmitted, true means the data terminal equipment is not ready
+	DTEReady    bool // The data terminal equipment is ready, false if the message doesn't carry the flag
 }
 
 // DecodeStaticVoyageData decodes [the payload of] an AIS Static and Voyage Related Data message (type 5)
@@ -88,10 +90,43 @@ func DecodeStaticVoyageData(payload string) (StaticVoyageData, error) {
 	m.Destination = bitsToString(302, 421, data)
 
 	m.DTE = cbnBool(422, data)
+	// Some transponders send the message two bits short, without the flag
+	m.DTEReady = len(data)*6 > 422 && !m.DTE
 
 	return m, nil
 }
 
+// ParseUNLOCODE returns the UN/LOCODE port code of the destination, if the destination is one.
+// A LOCODE is a two letter country code followed by a three character location code (letters and
+// digits 2-9), e.g "GRPIR". Many transmitters separate the two parts with a space or a dash,
+// e.g "GR PIR", which we accept too. The destination field is already trimmed of its trailing @
+// padding and spaces during decoding.
+func (m StaticVoyageData) ParseUNLOCODE() (string, bool) {
+	return parseUNLOCODE(m.Destination)
+}
+
+// parseUNLOCODE returns the UN/LOCODE text holds, see ParseUNLOCODE.
+func parseUNLOCODE(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if len(text) == 6 && (text[2] == ' ' || text[2] == '-') {
+		text = text[:2] + text[3:]
+	}
+	if len(text) != 5 {
+		return "", false
+	}
+
+	for i := 0; i < 5; i++ {
+		c := text[i]
+		switch {
+		case c >= 'A' && c <= 'Z':
+		case i >= 2 && c >= '2' && c <= '9':
+		default:
+			return "", false
+		}
+	}
+	return text, true
+}
+
 // Ship types codes.
 var ShipType = map[int]string{
 	0:  "Not available",
@@ -35,7 +35,7 @@ func TestDecodeStaticVoyageData(t *testing.T) {
 			StaticVoyageData{
 				Repeat: 0, MMSI: 265731560, AisVersion: 0, IMO: 8026361, Callsign: "SBTI",
 				VesselName: "TOFTE", ShipType: 52, ToBow: 7, ToStern: 17, ToPort: 4, ToStarboard: 4,
-				EPFD: 1, ETA: caseTime1, Draught: 40, Destination: "GOTEBORG", DTE: false,
+				EPFD: 1, ETA: caseTime1, Draught: 40, Destination: "GOTEBORG", DTE: false, DTEReady: true,
 			},
 		},
 		{
@@ -43,7 +43,16 @@ func TestDecodeStaticVoyageData(t *testing.T) {
 			StaticVoyageData{
 				Repeat: 0, MMSI: 257556700, AisVersion: 1, IMO: 0, Callsign: "LF5477",
 				VesselName: "RESCUE B", ShipType: 0, ToBow: 0, ToStern: 0, ToPort: 0, ToStarboard: 0,
-				EPFD: 0, ETA: caseTime2, Draught: 0, Destination: "", DTE: false,
+				EPFD: 0, ETA: caseTime2, Draught: 0, Destination: "", DTE: false, DTEReady: false,
+			},
+		},
+		{
+			// The first case, assembled from two fragments, with the DTE not ready
+			armor(payloadBits("53uJur01rN?U<9@T001@tI@F000000000000000l0pA4" + "44mm?:1km1@SlQp000000000000")[:422] + "1" + "0"),
+			StaticVoyageData{
+				Repeat: 0, MMSI: 265731560, AisVersion: 0, IMO: 8026361, Callsign: "SBTI",
+				VesselName: "TOFTE", ShipType: 52, ToBow: 7, ToStern: 17, ToPort: 4, ToStarboard: 4,
+				EPFD: 1, ETA: caseTime1, Draught: 40, Destination: "GOTEBORG", DTE: true, DTEReady: false,
 			},
 		},
 	}
@@ -57,8 +66,34 @@ func TestDecodeStaticVoyageData(t *testing.T) {
 	}
 }
 
+// See BenchmarkDecodeClassAPositionReport for the acceptance threshold.
 func BenchmarkDecodeStaticVoyageData(b *testing.B) {
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		DecodeStaticVoyageData("53uJur01rN?U<9@T001@tI@F000000000000000l0pA444mm?:1km1@SlQp000000000000")
 	}
 }
+
+func TestParseUNLOCODE(t *testing.T) {
+	cases := []struct {
+		destination, locode string
+		ok                  bool
+	}{
+		{"GRPIR", "GRPIR", true},
+		{"GR PIR", "GRPIR", true},
+		{"SE-GOT", "SEGOT", true},
+		{"USNYC", "USNYC", true},
+		{"NLRT2", "NLRT2", true},
+		{"GOTEBORG", "", false},
+		{"GR1IR", "", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		got, ok := StaticVoyageData{Destination: c.destination}.ParseUNLOCODE()
+		if got != c.locode || ok != c.ok {
+			fmt.Println("Got : ", got, ok)
+			fmt.Println("Want: ", c.locode, c.ok)
+			t.Errorf("(StaticVoyageData) ParseUNLOCODE()")
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"math"
+	"time"
+)
+
+// A TrackPoint is a single fix of a vessel's track. Coordinates are in decimal degrees,
+// as returned from the decoding functions. Longitude 181 and latitude 91 mean not available.
+// Time is when the fix was taken. AIS position reports carry only the second of the minute, so
+// it is up to the user to set it, e.g from the receive time of the sentence.
+// Speed and Course are as reported (knots and degrees), 1023 and 360 mean not available.
+type TrackPoint struct {
+	Lon    float64
+	Lat    float64
+	Time   time.Time
+	Speed  float32
+	Course float32
+}
+
+// ReconcileTime returns the most plausible time a report with the given second of the minute
+// (timestamp field) was taken, given the time receivedAt it was received. It is the time with that
+// second nearest to receivedAt, so it handles the minute rolling over between the report and its
+// reception (e.g second 58 received at 12:01:02 is 12:00:58). Half a minute apart, the past one is
+// chosen, as reports are received after they are taken. It returns false for the not
+// available values (60 and above: not available, manual input, dead reckoning, inoperative).
+func ReconcileTime(second uint8, receivedAt time.Time) (time.Time, bool) {
+	if second >= 60 {
+		return time.Time{}, false
+	}
+
+	t := receivedAt.Truncate(time.Minute).Add(time.Duration(second) * time.Second)
+	switch offset := t.Sub(receivedAt); {
+	case offset >= 30*time.Second:
+		t = t.Add(-time.Minute)
+	case offset < -30*time.Second:
+		t = t.Add(time.Minute)
+	}
+	return t, true
+}
+
+// DefaultDeadReckoningHorizon is the maximum time DeadReckon projects a position forward unless
+// WithHorizon says otherwise, so a stale contact doesn't drift across the ocean.
+const DefaultDeadReckoningHorizon = 10 * time.Minute
+
+// A DeadReckonOption changes how DeadReckon projects a position.
+type DeadReckonOption func(*deadReckonOptions)
+
+type deadReckonOptions struct {
+	horizon time.Duration
+}
+
+// WithHorizon sets the maximum time DeadReckon projects a position forward, instead of
+// DefaultDeadReckoningHorizon. A horizon of 0 means no limit.
+func WithHorizon(horizon time.Duration) DeadReckonOption {
+	return func(o *deadReckonOptions) {
+		o.horizon = horizon
+	}
+}
+
+// Mean earth radius in meters and meters per nautical mile, used for distance calculations.
+const (
+	earthRadius  = 6371000.0
+	nauticalMile = 1852.0
+)
+
+// available reports whether the track point carries a valid position (not the not-available sentinels).
+func (p TrackPoint) available() bool {
+	return positionAvailable(p.Lon, p.Lat)
+}
+
+// CourseMadeGood returns the course (degrees true) a vessel actually followed from prev to cur,
+// the initial great circle bearing between the two fixes. It can be compared against the
+// reported COG to find implausible reports. It returns false if the two positions are identical
+// or if either of them isn't available.
+func CourseMadeGood(prev, cur TrackPoint) (float64, bool) {
+	if !prev.available() || !cur.available() || (prev.Lon == cur.Lon && prev.Lat == cur.Lat) {
+		return 0, false
+	}
+
+	course := bearing(prev, cur) * 180 / math.Pi
+	return math.Mod(course+360, 360), true
+}
+
+// bearing returns the initial great circle bearing from a to b in radians, in (-π, π].
+func bearing(a, b TrackPoint) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return math.Atan2(y, x)
+}
+
+// ImpliedSpeed returns the speed (knots) a vessel must have had to travel from prev to cur in the time
+// elapsed between the two fixes. It can be compared against the reported SOG to find spoofed or
+// erroneous reports. It returns false if the elapsed time is zero or negative or if either
+// position isn't available.
+func ImpliedSpeed(prev, cur TrackPoint) (float64, bool) {
+	elapsed := cur.Time.Sub(prev.Time)
+	if elapsed <= 0 || !prev.available() || !cur.available() {
+		return 0, false
+	}
+
+	return distance(prev, cur) / nauticalMile / elapsed.Hours(), true
+}
+
+// distance returns the great circle distance between two track points in meters (haversine formula).
+func distance(a, b TrackPoint) float64 {
+	lat1 := a.Lat * math.Pi / 180
+	lat2 := b.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// DeadReckon estimates the position of a vessel at time at, projecting its last known position
+// along its course at its speed (great circle). It is useful in order to move markers smoothly
+// between reports. The projection is capped to the horizon (see WithHorizon) after the last fix
+// and the returned point's Time is the time the estimate is for. If the speed is zero or the
+// speed, course or position aren't available, or at isn't after the last fix, last is returned
+// unchanged.
+func DeadReckon(last TrackPoint, at time.Time, options ...DeadReckonOption) TrackPoint {
+	o := deadReckonOptions{horizon: DefaultDeadReckoningHorizon}
+	for _, option := range options {
+		option(&o)
+	}
+
+	elapsed := at.Sub(last.Time)
+	if elapsed <= 0 || last.Speed == 0 || last.Speed >= 1022 || last.Course >= 360 || !last.available() {
+		return last
+	}
+	if o.horizon > 0 && elapsed > o.horizon {
+		elapsed = o.horizon
+	}
+
+	d := float64(last.Speed) * nauticalMile * elapsed.Hours() / earthRadius // angular distance
+	course := float64(last.Course) * math.Pi / 180
+	lat1 := last.Lat * math.Pi / 180
+	lon1 := last.Lon * math.Pi / 180
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(course))
+	lon2 := lon1 + math.Atan2(math.Sin(course)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+
+	p := last
+	p.Lat = lat2 * 180 / math.Pi
+	p.Lon = math.Mod(lon2*180/math.Pi+540, 360) - 180
+	p.Time = last.Time.Add(elapsed)
+	return p
+}
+
+// Simplify reduces the number of points of a track with the Ramer-Douglas-Peucker algorithm, so that
+// exports (e.g GPX or KML) stay compact. Points closer than toleranceMeters to the simplified track
+// are dropped, the rest are returned unchanged (with their times) and in order. The first and last
+// points are always kept. Points without an available position are dropped. A negative or NaN
+// tolerance is taken as 0.
+func Simplify(points []TrackPoint, toleranceMeters float64) []TrackPoint {
+	var track []TrackPoint
+	for _, p := range points {
+		if p.available() {
+			track = append(track, p)
+		}
+	}
+	if len(track) < 3 {
+		return track
+	}
+	if !(toleranceMeters >= 0) {
+		toleranceMeters = 0
+	}
+
+	keep := make([]bool, len(track))
+	keep[0], keep[len(track)-1] = true, true
+	simplify(track, 0, len(track)-1, toleranceMeters, keep)
+
+	simplified := make([]TrackPoint, 0, len(track))
+	for i, p := range track {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+	return simplified
+}
+
+// simplify marks the points between first and last that are farther than tolerance from the
+// segment first-last to be kept, and recurses on the two halves around the farthest one.
+func simplify(track []TrackPoint, first, last int, tolerance float64, keep []bool) {
+	if last-first < 2 { // No points in between
+		return
+	}
+	farthest, max := 0, 0.0
+	for i := first + 1; i < last; i++ {
+		if d := segmentDistance(track[i], track[first], track[last]); d > max {
+			farthest, max = i, d
+		}
+	}
+	if max <= tolerance {
+		return
+	}
+
+	keep[farthest] = true
+	simplify(track, first, farthest, tolerance, keep)
+	simplify(track, farthest, last, tolerance, keep)
+}
+
+// segmentDistance returns the distance in meters from p to the great circle segment a-b: the
+// cross-track distance if p is abeam the segment, else the distance to the nearest end.
+func segmentDistance(p, a, b TrackPoint) float64 {
+	if a.Lon == b.Lon && a.Lat == b.Lat {
+		return distance(a, p)
+	}
+
+	dAP := distance(a, p)
+	angle := bearing(a, p) - bearing(a, b)
+	if math.Cos(angle) < 0 { // p is behind a
+		return dAP
+	}
+
+	crossTrack := math.Asin(math.Sin(dAP/earthRadius) * math.Sin(angle))
+	alongTrack := math.Acos(math.Cos(dAP/earthRadius)/math.Cos(crossTrack)) * earthRadius
+	if alongTrack > distance(a, b) { // p is past b
+		return distance(b, p)
+	}
+	return math.Abs(crossTrack) * earthRadius
+}
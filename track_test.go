@@ -0,0 +1,198 @@
+// Copyright (c) 2015, Marios Andreopoulos.
+//
+// This file is part of aislib.
+//
+//  Aislib is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+//  Aislib is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+//  You should have received a copy of the GNU General Public License
+// along with aislib.  If not, see <http://www.gnu.org/licenses/>.
+
+package aislib
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCourseMadeGood(t *testing.T) {
+	cases := []struct {
+		prev, cur TrackPoint
+		course    float64
+		ok        bool
+	}{
+		{TrackPoint{Lon: 23.0, Lat: 37.0}, TrackPoint{Lon: 23.0, Lat: 37.1}, 0, true},
+		{TrackPoint{Lon: 23.0, Lat: 37.0}, TrackPoint{Lon: 23.1, Lat: 37.0}, 89.97, true},
+		{TrackPoint{Lon: 23.0, Lat: 37.0}, TrackPoint{Lon: 23.0, Lat: 36.9}, 180, true},
+		{TrackPoint{Lon: 23.0, Lat: 37.0}, TrackPoint{Lon: 22.9, Lat: 37.0}, 270.03, true},
+		{TrackPoint{Lon: 23.0, Lat: 37.0}, TrackPoint{Lon: 23.0, Lat: 37.0}, 0, false},
+		{TrackPoint{Lon: 181, Lat: 91}, TrackPoint{Lon: 23.0, Lat: 37.0}, 0, false},
+	}
+	for _, c := range cases {
+		got, ok := CourseMadeGood(c.prev, c.cur)
+		if ok != c.ok || math.Abs(got-c.course) > 0.01 {
+			fmt.Println("Got : ", got, ok)
+			fmt.Println("Want: ", c.course, c.ok)
+			t.Errorf("CourseMadeGood(prev, cur TrackPoint)")
+		}
+	}
+}
+
+func TestImpliedSpeed(t *testing.T) {
+	start := time.Date(2015, 2, 4, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		prev, cur TrackPoint
+		speed     float64
+		ok        bool
+	}{
+		// One minute of latitude is one nautical mile
+		{TrackPoint{Lon: 23.0, Lat: 37.0, Time: start}, TrackPoint{Lon: 23.0, Lat: 37.0 + 1.0/60, Time: start.Add(6 * time.Minute)}, 10.01, true},
+		{TrackPoint{Lon: 23.0, Lat: 37.0, Time: start}, TrackPoint{Lon: 23.0, Lat: 37.0, Time: start.Add(time.Minute)}, 0, true},
+		{TrackPoint{Lon: 23.0, Lat: 37.0, Time: start}, TrackPoint{Lon: 23.0, Lat: 37.1, Time: start}, 0, false},
+		{TrackPoint{Lon: 23.0, Lat: 37.0, Time: start}, TrackPoint{Lon: 23.0, Lat: 37.1, Time: start.Add(-time.Minute)}, 0, false},
+		{TrackPoint{Lon: 181, Lat: 91, Time: start}, TrackPoint{Lon: 23.0, Lat: 37.1, Time: start.Add(time.Minute)}, 0, false},
+	}
+	for _, c := range cases {
+		got, ok := ImpliedSpeed(c.prev, c.cur)
+		if ok != c.ok || math.Abs(got-c.speed) > 0.01 {
+			fmt.Println("Got : ", got, ok)
+			fmt.Println("Want: ", c.speed, c.ok)
+			t.Errorf("ImpliedSpeed(prev, cur TrackPoint)")
+		}
+	}
+}
+
+func TestDeadReckon(t *testing.T) {
+	start := time.Date(2015, 2, 4, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		last    TrackPoint
+		at      time.Time
+		options []DeadReckonOption
+		want    TrackPoint
+	}{
+		// Ten knots north for six minutes is one nautical mile, one minute of latitude
+		{
+			TrackPoint{23.0, 37.0, start, 10, 0}, start.Add(6 * time.Minute), nil,
+			TrackPoint{23.0, 37.0 + 1.0/60, start.Add(6 * time.Minute), 10, 0},
+		},
+		// Capped to DefaultDeadReckoningHorizon
+		{
+			TrackPoint{23.0, 37.0, start, 6, 180}, start.Add(5 * time.Hour), nil,
+			TrackPoint{23.0, 37.0 - 1.0/60, start.Add(DefaultDeadReckoningHorizon), 6, 180},
+		},
+		{
+			TrackPoint{23.0, 37.0, start, 6, 180}, start.Add(5 * time.Hour), []DeadReckonOption{WithHorizon(20 * time.Minute)},
+			TrackPoint{23.0, 37.0 - 2.0/60, start.Add(20 * time.Minute), 6, 180},
+		},
+		// No limit
+		{
+			TrackPoint{23.0, 37.0, start, 6, 180}, start.Add(time.Hour), []DeadReckonOption{WithHorizon(0)},
+			TrackPoint{23.0, 37.0 - 6.0/60, start.Add(time.Hour), 6, 180},
+		},
+		// Crossing the antimeridian eastwards
+		{
+			TrackPoint{179.99, 0, start, 60, 90}, start.Add(time.Minute), nil,
+			TrackPoint{-179.993, 0, start.Add(time.Minute), 60, 90},
+		},
+		{TrackPoint{23.0, 37.0, start, 0, 90}, start.Add(time.Minute), nil, TrackPoint{23.0, 37.0, start, 0, 90}},
+		{TrackPoint{23.0, 37.0, start, 1023, 90}, start.Add(time.Minute), nil, TrackPoint{23.0, 37.0, start, 1023, 90}},
+		{TrackPoint{23.0, 37.0, start, 10, 360}, start.Add(time.Minute), nil, TrackPoint{23.0, 37.0, start, 10, 360}},
+		{TrackPoint{23.0, 37.0, start, 10, 90}, start.Add(-time.Minute), nil, TrackPoint{23.0, 37.0, start, 10, 90}},
+	}
+	for _, c := range cases {
+		got := DeadReckon(c.last, c.at, c.options...)
+		if math.Abs(got.Lon-c.want.Lon) > 0.001 || math.Abs(got.Lat-c.want.Lat) > 0.001 ||
+			!got.Time.Equal(c.want.Time) || got.Speed != c.want.Speed || got.Course != c.want.Course {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("DeadReckon(last TrackPoint, at time.Time, options ...DeadReckonOption)")
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	start := time.Date(2015, 2, 4, 0, 0, 0, 0, time.UTC)
+	var straight []TrackPoint
+	for i := 0; i <= 10; i++ {
+		straight = append(straight, TrackPoint{Lon: 23.0, Lat: 37.0 + float64(i)/600, Time: start.Add(time.Duration(i) * time.Minute)})
+	}
+	// A dog leg: north, then east, with about 10 meters of noise on each leg
+	dogLeg := []TrackPoint{
+		{Lon: 23.0, Lat: 37.0, Time: start},
+		{Lon: 23.0001, Lat: 37.05, Time: start.Add(time.Minute)},
+		{Lon: 23.0, Lat: 37.1, Time: start.Add(2 * time.Minute)},
+		{Lon: 23.05, Lat: 37.1001, Time: start.Add(3 * time.Minute)},
+		{Lon: 23.1, Lat: 37.1, Time: start.Add(4 * time.Minute)},
+		{Lon: 181, Lat: 91, Time: start.Add(5 * time.Minute)},
+	}
+
+	cases := []struct {
+		points    []TrackPoint
+		tolerance float64
+		want      []TrackPoint
+	}{
+		{straight, 10, []TrackPoint{straight[0], straight[10]}},
+		{straight, 0, []TrackPoint{straight[0], straight[10]}},
+		{dogLeg, 20, []TrackPoint{dogLeg[0], dogLeg[2], dogLeg[4]}},
+		{dogLeg, 1, dogLeg[:5]},
+		{dogLeg[:2], 10, dogLeg[:2]},
+		// Taken as 0
+		{straight[:3], -1, []TrackPoint{straight[0], straight[2]}},
+		{dogLeg, math.NaN(), dogLeg[:5]},
+		{nil, 10, nil},
+	}
+	for _, c := range cases {
+		got := Simplify(c.points, c.tolerance)
+		if len(got) != len(c.want) {
+			fmt.Println("Got : ", got)
+			fmt.Println("Want: ", c.want)
+			t.Errorf("Simplify(points []TrackPoint, toleranceMeters float64)")
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				fmt.Println("Got : ", got)
+				fmt.Println("Want: ", c.want)
+				t.Errorf("Simplify(points []TrackPoint, toleranceMeters float64)")
+				break
+			}
+		}
+	}
+}
+
+func TestReconcileTime(t *testing.T) {
+	at := func(h, m, s int) time.Time { return time.Date(2015, 2, 4, h, m, s, 0, time.UTC) }
+	cases := []struct {
+		second     uint8
+		receivedAt time.Time
+		want       time.Time
+		ok         bool
+	}{
+		{30, at(12, 0, 31), at(12, 0, 30), true},
+		{58, at(12, 1, 2), at(12, 0, 58), true}, // Minute rolled over before reception
+		{2, at(12, 0, 58), at(12, 1, 2), true},  // Receiver clock behind
+		{0, at(23, 59, 50), at(0, 0, 0).Add(24 * time.Hour), true},
+		{59, at(0, 0, 1), at(23, 59, 59).Add(-24 * time.Hour), true},
+		{31, at(12, 1, 1), at(12, 0, 31), true}, // Exactly 30 seconds prefers the past
+		{15, time.Date(2015, 2, 4, 12, 0, 15, 500000000, time.UTC), at(12, 0, 15), true},
+		{60, at(12, 0, 0), time.Time{}, false},
+		{63, at(12, 0, 0), time.Time{}, false},
+	}
+	for _, c := range cases {
+		got, ok := ReconcileTime(c.second, c.receivedAt)
+		if ok != c.ok || !got.Equal(c.want) {
+			fmt.Println("Got : ", got, ok)
+			fmt.Println("Want: ", c.want, c.ok)
+			t.Errorf("ReconcileTime(second uint8, receivedAt time.Time) for second %d at %s", c.second, c.receivedAt)
+		}
+	}
+}